@@ -7,6 +7,7 @@ toolchain go1.23.2
 require (
 	github.com/awesome-gocui/gocui v1.1.0
 	golang.org/x/text v0.23.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -3,19 +3,25 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"cmp"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"html"
 	"io"
 	"log"
+	"maps"
 	"math"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -26,6 +32,7 @@ import (
 	"github.com/awesome-gocui/gocui"
 	"golang.org/x/text/encoding/charmap"
 	"golang.org/x/text/encoding/unicode"
+	"gopkg.in/yaml.v3"
 )
 
 var programVersion string = "0.7.6"
@@ -46,14 +53,100 @@ type DockerContainers struct {
 	id   string
 }
 
+// Список значений для аргументов, которые можно указать несколько раз
+type stringList []string
+
+func (list *stringList) String() string {
+	return strings.Join(*list, ", ")
+}
+
+func (list *stringList) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+// Состояние окна вывода журнала (источник, фильтр, позиция прокрутки и выделение)
+// Активное окно встроено в App, второе окно режима сравнения и неактивные вкладки хранятся отдельно и меняются с активным местами
+type logPane struct {
+	background bool // окно выводится во втором окне (logs2) и не принимает ввод
+	hidden     bool // состояние неактивной вкладки (вывод в невидимое окно logsTab)
+	side       int  // расположение окна в режиме сравнения (0 - слева, 1 - справа)
+
+	filterMode string // режим фильтрации окна, пока оно не активно (default/fuzzy/regex)
+	newErrors  int    // количество новых строк с ошибками, загруженных в неактивной вкладке
+	pinned     bool   // проверять новые строки по правилам оповещений, когда окно или вкладка не активны
+
+	filterText       string   // текст для фильтрации записей журнала
+	currentLogLines  []string // набор строк (срез) для хранения журнала без фильтрации
+	filteredLogLines []string // набор строк (срез) для хранения журнала после фильтра
+	logScrollPos     int      // позиция прокрутки для отображаемых строк журнала
+	lastFilterText   string   // фиксируем содержимое последнего ввода текста для фильтрации
+
+	jsonLines map[string]*jsonLine // разобранные строки JSON текущего журнала (ключ - исходная строка)
+
+	foldedGroups  map[int]bool  // многострочные записи, состояние которых отличается от foldAllGroups (ключ - номер первой строки записи)
+	filteredLines []logLine     // строки вывода с позицией в исходном журнале (соответствуют filteredLogLines)
+	fileLines     fileLineCount // количество строк в текущем файле журнала для вычисления номеров строк
+
+	logPatterns   []*logPattern   // шаблоны сообщений текущего журнала в окне Patterns
+	patternFilter map[string]bool // маскированные строки выбранного шаблона для фильтрации вывода (nil, если шаблон не выбран)
+
+	bookmarkedLines []int // индексы строк вывода с закладками текущего источника
+
+	logScrollX int // позиция горизонтальной прокрутки вывода журнала в режиме без переноса строк
+
+	selectMode  bool // режим выделения строк вывода журнала для копирования
+	selectStart int  // индекс строки, с которой начато выделение
+	selectEnd   int  // индекс строки, которая перемещается стрелками
+
+	exportStructured bool // в текущем выводе есть структурированные строки (доступна выгрузка в JSON lines)
+
+	timelineBuckets  []timelineBucket // интервалы времени с количеством строк для панели Timeline
+	timelineUnit     time.Duration    // размер интервала (минута, час, день или несколько дней)
+	selectedTimeline int              // индекс выбранного интервала в панели Timeline
+
+	autoScroll     bool   // используется для автоматического скроллинга вниз при обновлении (если это не ручной скроллинг)
+	newUpdateIndex int    // фиксируем текущую длинну массива (индекс) для вставки строки обновления (если это ручной выбор из списка)
+	updateTime     string // время загрузки журнала для делиметра
+
+	lastDateUpdateFile time.Time // последняя дата изменения файла
+	lastSizeFile       int64     // размер файла
+	updateFile         bool      // проверка для обновления вывода в горутине (отключение только если нет изменений в файле и для Windows Event)
+
+	lastWindow   string // фиксируем последний используемый источник для вывода логов
+	lastSelected string // фиксируем название последнего выбранного журнала или контейнера
+	lastHost     string // удаленный хост, с которого загружен журнал (пусто для локального хоста)
+
+	// Переменные для хранения значений автообновления вывода при смене окна
+	lastSelectUnits            string
+	lastBootId                 string
+	lastLogPath                string
+	lastContainerizationSystem string
+	lastContainerId            string
+	previousInstance           bool       // журнал предыдущего (завершившегося) экземпляра контейнера пода (kubectl logs --previous)
+	stream                     *logStream // поток журнала контейнера пода (kubectl logs -f)
+	loadError                  error      // ошибка последней загрузки журнала без интерфейса (выводится в режиме наблюдения)
+	loaded                     bool       // первая загрузка источника режима наблюдения выполнена (ее строки не проверяются правилами)
+
+	// Фиксируем последнее время загрузки журнала
+	debugLoadTime string
+}
+
 // Структура основного приложения (графический интерфейс и данные журналов)
 type App struct {
 	gui *gocui.Gui // графический интерфейс (gocui)
 
 	testMode     bool // исключаем вызовы к gocui при тестирование функций
+	headless     bool // работа без интерфейса в режиме наблюдения (вызовы к gocui исключаются, ошибки загрузки записываются в loadError)
 	tailSpinMode bool // режим покраски через tailspin
 	colorMode    bool // отключение/включение покраски ключевых слов
 
+	jsonExpandMode bool // развернутый вывод (pretty-print) строк в формате JSON
+	dedupMode      bool // объединение повторяющихся строк с количеством повторений
+	timelineMode   bool // отображение панели с гистограммой количества строк по времени
+
+	accessLogFormats []*accessLogFormat // форматы журналов доступа веб-серверов (пользовательские и стандартные)
+
 	getOS         string   // название ОС
 	getArch       string   // архитектура процессора
 	hostName      string   // текущее имя хоста для покраски в логах
@@ -78,52 +171,74 @@ type App struct {
 	startFiles      int
 	selectedFile    int
 
+	dockerContainers           []DockerContainers
 	maxVisibleDockerContainers int
 	startDockerContainers      int
 	selectedDockerContainer    int
+	containerFilesHost         string // контейнер, файлы которого выводятся в окне файловой системы (docker://<container>)
+
+	// Текущий уровень списка Kubernetes: пространства имен (пустое пространство имен), поды или контейнеры пода
+	kubeNamespace string
+	kubePod       string
+	kubePods      []kubePod // поды выбранного пространства имен из последней загрузки списка
 
 	filterListText string // текст для фильтрации список журналов
 
 	// Массивы для хранения списка журналов без фильтрации
-	journalsNotFilter []Journal
-	logfilesNotFilter []Logfile
+	journalsNotFilter         []Journal
+	logfilesNotFilter         []Logfile
+	dockerContainersNotFilter []DockerContainers
 
 	// Переменные для отслеживания изменений размера окна
 	windowWidth  int
 	windowHeight int
 
-	filterText       string   // текст для фильтрации записей журнала
-	currentLogLines  []string // набор строк (срез) для хранения журнала без фильтрации
-	filteredLogLines []string // набор строк (срез) для хранения журнала после фильтра
-	logScrollPos     int      // позиция прокрутки для отображаемых строк журнала
-	lastFilterText   string   // фиксируем содержимое последнего ввода текста для фильтрации
+	logPane             // состояние вывода активного окна журнала (источник, фильтр и позиция прокрутки)
+	splitPane  *logPane // состояние второго окна журнала в режиме сравнения (nil, если режим отключен)
+	syncScroll bool     // синхронизировать прокрутку второго окна по времени строк
 
-	autoScroll     bool   // используется для автоматического скроллинга вниз при обновлении (если это не ручной скроллинг)
-	newUpdateIndex int    // фиксируем текущую длинну массива (индекс) для вставки строки обновления (если это ручной выбор из списка)
-	updateTime     string // время загрузки журнала для делиметра
+	tabs       []*logPane // вкладки открытых журналов (элемент активной вкладки заполняется при переключении)
+	activeTab  int        // индекс активной вкладки
+	tabBarEnds []int      // позиции окончания названий вкладок в панели вкладок для выбора мышью
 
-	lastDateUpdateFile time.Time // последняя дата изменения файла
-	lastSizeFile       int64     // размер файла
-	updateFile         bool      // проверка для обновления вывода в горутине (отключение только если нет изменений в файле и для Windows Event)
+	foldAllGroups bool // сворачивать все многострочные записи (стек вызовов) по умолчанию
+	lineNumbers   bool // выводить номера строк исходного журнала
 
-	lastWindow   string // фиксируем последний используемый источник для вывода логов
-	lastSelected string // фиксируем название последнего выбранного журнала или контейнера
+	bookmarks    []bookmark // закладки на строках журналов с заметками
+	sessionFile  string     // файл сессии для сохранения закладок
+	sessionError string     // ошибка чтения или записи файла сессии (выводится в заголовке окна журнала)
+	bookmarkList []int      // порядок закладок в окне Bookmarks (индексы в bookmarks)
+	noteLine     int        // индекс строки вывода, для которой редактируется заметка
 
-	// Переменные для хранения значений автообновления вывода при смене окна
-	lastSelectUnits            string
-	lastBootId                 string
-	lastLogPath                string
-	lastContainerizationSystem string
-	lastContainerId            string
+	mouse        bool              // включить поддержку мыши
+	layoutPreset string            // вариант расположения окон (columns - списки слева, rows - списки снизу)
+	listsSize    int               // размер панели списков, измененный пользователем (0 - размер по умолчанию)
+	zoomLogs     bool              // полноэкранный режим вывода журнала
+	mouseDrag    string            // окно, которое перетаскивается мышью (граница колонок или полоса прокрутки)
+	viewCursors  map[string][2]int // позиции курсоров окон после последнего обновления интерфейса
+
+	noWrapMode bool // режим вывода журнала без переноса длинных строк с горизонтальной прокруткой
+
+	exportFormat    string // формат выгрузки текущего вывода в файл (text/ansi/html/json)
+	exportOverwrite string // имя существующего файла выгрузки, перезапись которого подтверждается повторным нажатием Enter
+
+	diffLines int // количество строк в окне сравнения журналов Diff
+
+	alertRules   []alertRule // правила оповещений о новых строках журнала
+	noAlertBell  bool        // отключить звуковой сигнал терминала при срабатывании оповещений
+	alertHits    []alertHit  // срабатывания правил оповещений (в порядке появления)
+	alertsUnseen int         // количество срабатываний после последнего открытия окна Alerts
+
+	host        string            // удаленный хост для загрузки списков и журналов (пусто для локального хоста)
+	runner      CommandRunner     // исполнитель команд текущего хоста
+	hosts       []string          // удаленные хосты для переключения (из параметров и файла hosts)
+	hostSystems map[string]string // операционные системы подключенных удаленных хостов (вывод uname)
 
 	// Цвета окон по умолчанию (изменяется в зависимости от доступности журналов)
 	journalListFrameColor gocui.Attribute
 	fileSystemFrameColor  gocui.Attribute
 	dockerFrameColor      gocui.Attribute
 
-	// Фиксируем последнее время загрузки журнала
-	debugLoadTime string
-
 	// Отключение привязки горячих клавиш на время загрузки списка
 	keybindingsEnabled bool
 
@@ -152,6 +267,21 @@ func showHelp() {
 	fmt.Println("    lazyjournal --help, -h     Show help")
 	fmt.Println("    lazyjournal --version, -v  Show version")
 	fmt.Println("    lazyjournal --audit, -a    Show audit information")
+	fmt.Println("    lazyjournal --log-format, -l <format>")
+	fmt.Println("                               Custom access log format in the nginx log_format or Apache LogFormat syntax (can be repeated)")
+	fmt.Println("    lazyjournal --session, -s <file>")
+	fmt.Println("                               Session file for saving bookmarks (default: lazyjournal/session.json in the user config directory)")
+	fmt.Println("    lazyjournal --mouse, -m")
+	fmt.Println("                               Enable mouse support (selecting text with the mouse in the terminal is not available)")
+	fmt.Println("    lazyjournal --alert, -A <severity:regex>")
+	fmt.Println("                               Alert rule for new log lines, severity is error, warning or info (can be repeated)")
+	fmt.Println("    lazyjournal --no-alert-bell, -b")
+	fmt.Println("                               Do not ring the terminal bell on alerts")
+	fmt.Println("    lazyjournal --host, -H <user@server>")
+	fmt.Println("                               Read logs from a remote host over SSH or inside a container (docker://<container>,")
+	fmt.Println("                               podman://<container>, kubectl://[namespace/]<pod>[/container]), can be repeated")
+	fmt.Println("    lazyjournal watch -config <rules.yml>")
+	fmt.Println("                               Watch units and files without the interface and write rule matches to stdout, a file or a webhook")
 }
 
 func (app *App) showVersion() {
@@ -277,7 +407,7 @@ func (app *App) showAudit() {
 			"systemd:",
 			"  journald:",
 		)
-		csCheck := exec.Command("journalctl", "--version")
+		csCheck := app.command("journalctl", "--version")
 		_, err := csCheck.Output()
 		if err == nil {
 			auditText = append(auditText,
@@ -370,33 +500,35 @@ var g *gocui.Gui
 func runGoCui(mock bool) {
 	// Инициализация значений по умолчанию + компиляция регулярных выражений для покраски
 	app := &App{
-		testMode:              false,
-		tailSpinMode:          false,
-		colorMode:             true,
-		startServices:         0, // начальная позиция списка юнитов
-		selectedJournal:       0, // начальный индекс выбранного журнала
-		startFiles:            0,
-		selectedFile:          0,
-		selectUnits:           "services",  // "UNIT" || "USER_UNIT" || "kernel"
-		selectPath:            "/var/log/", // "/opt/", "/home/" или "/Users/" (для MacOS) + /root/
-		selectFilterMode:      "default",   // "fuzzy" || "regex"
-		logViewCount:          "200000",    // 5000-300000
-		journalListFrameColor: gocui.ColorDefault,
-		fileSystemFrameColor:  gocui.ColorDefault,
-		autoScroll:            true,
-		trimHttpRegex:         trimHttpRegex,
-		trimHttpsRegex:        trimHttpsRegex,
-		trimPrefixPathRegex:   trimPrefixPathRegex,
-		trimPostfixPathRegex:  trimPostfixPathRegex,
-		hexByteRegex:          hexByteRegex,
-		dateTimeRegex:         dateTimeRegex,
-		timeMacAddressRegex:   timeMacAddressRegex,
-		dateIpAddressRegex:    dateIpAddressRegex,
-		dateRegex:             dateRegex,
-		ipAddressRegex:        ipAddressRegex,
-		procRegex:             procRegex,
-		syslogUnitRegex:       syslogUnitRegex,
-		keybindingsEnabled:    true,
+		testMode:                     false,
+		tailSpinMode:                 false,
+		colorMode:                    true,
+		startServices:                0, // начальная позиция списка юнитов
+		selectedJournal:              0, // начальный индекс выбранного журнала
+		startFiles:                   0,
+		selectedFile:                 0,
+		selectUnits:                  "services",  // "UNIT" || "USER_UNIT" || "kernel"
+		selectPath:                   "/var/log/", // "/opt/", "/home/" или "/Users/" (для MacOS) + /root/
+		selectContainerizationSystem: "docker",    // "podman" || "kubectl"
+		selectFilterMode:             "default",   // "fuzzy" || "regex"
+		logViewCount:                 "200000",    // 5000-300000
+		journalListFrameColor:        gocui.ColorDefault,
+		fileSystemFrameColor:         gocui.ColorDefault,
+		dockerFrameColor:             gocui.ColorDefault,
+		logPane:                      logPane{autoScroll: true},
+		trimHttpRegex:                trimHttpRegex,
+		trimHttpsRegex:               trimHttpsRegex,
+		trimPrefixPathRegex:          trimPrefixPathRegex,
+		trimPostfixPathRegex:         trimPostfixPathRegex,
+		hexByteRegex:                 hexByteRegex,
+		dateTimeRegex:                dateTimeRegex,
+		timeMacAddressRegex:          timeMacAddressRegex,
+		dateIpAddressRegex:           dateIpAddressRegex,
+		dateRegex:                    dateRegex,
+		ipAddressRegex:               ipAddressRegex,
+		procRegex:                    procRegex,
+		syslogUnitRegex:              syslogUnitRegex,
+		keybindingsEnabled:           true,
 	}
 
 	// Определяем используемую ОС (linux/darwin/*bsd/windows) и архитектуру
@@ -410,6 +542,21 @@ func runGoCui(mock bool) {
 	flag.BoolVar(version, "v", false, "Show version")
 	audit := flag.Bool("audit", false, "Show audit information")
 	flag.BoolVar(audit, "a", false, "Show audit information")
+	var logFormats stringList
+	flag.Var(&logFormats, "log-format", "Custom access log format")
+	flag.Var(&logFormats, "l", "Custom access log format")
+	sessionFile := flag.String("session", defaultSessionFile(), "Session file for bookmarks")
+	flag.StringVar(sessionFile, "s", defaultSessionFile(), "Session file for bookmarks")
+	mouse := flag.Bool("mouse", false, "Enable mouse support")
+	flag.BoolVar(mouse, "m", false, "Enable mouse support")
+	var alerts stringList
+	var hosts stringList
+	flag.Var(&hosts, "host", "Remote host for reading logs over SSH")
+	flag.Var(&hosts, "H", "Remote host for reading logs over SSH")
+	flag.Var(&alerts, "alert", "Alert rule in the format severity:regex")
+	flag.Var(&alerts, "A", "Alert rule in the format severity:regex")
+	noAlertBell := flag.Bool("no-alert-bell", false, "Do not ring the terminal bell on alerts")
+	flag.BoolVar(noAlertBell, "b", false, "Do not ring the terminal bell on alerts")
 
 	// Обработка аргументов
 	flag.Parse()
@@ -425,6 +572,50 @@ func runGoCui(mock bool) {
 		app.showAudit()
 		os.Exit(0)
 	}
+	// Пользовательские форматы журналов доступа проверяются раньше стандартных
+	for i, logFormat := range logFormats {
+		accessFormat, err := parseAccessLogFormat("custom"+strconv.Itoa(i+1), logFormat)
+		if err != nil {
+			fmt.Println("Error log format:", err)
+			os.Exit(1)
+		}
+		app.accessLogFormats = append(app.accessLogFormats, accessFormat)
+	}
+	app.accessLogFormats = append(app.accessLogFormats, defaultAccessLogFormats...)
+	for _, alert := range alerts {
+		rule, err := parseAlertRule(alert)
+		if err != nil {
+			fmt.Println("Error alert rule:", err)
+			os.Exit(1)
+		}
+		app.alertRules = append(app.alertRules, rule)
+	}
+	app.mouse = *mouse
+	app.noAlertBell = *noAlertBell
+	// Подключаемся к первому удаленному хосту до запуска интерфейса (ssh может запросить пароль)
+	app.hosts = hosts
+	if hostsFile := defaultHostsFile(); hostsFile != "" {
+		fileHosts, err := loadHosts(hostsFile)
+		if err != nil {
+			fmt.Println("Error hosts file:", err)
+			os.Exit(1)
+		}
+		for _, host := range fileHosts {
+			if !slices.Contains(app.hosts, host) {
+				app.hosts = append(app.hosts, host)
+			}
+		}
+	}
+	if len(hosts) > 0 {
+		if err := app.connectHost(hosts[0], true); err != nil {
+			fmt.Println("Error connecting to host:", err)
+			os.Exit(1)
+		}
+		app.useHost(hosts[0])
+	}
+	// Загружаем закладки и расположение окон из файла сессии
+	app.sessionFile = *sessionFile
+	app.loadSessionFile()
 
 	// Создаем GUI
 	var err error
@@ -442,8 +633,8 @@ func runGoCui(mock bool) {
 	app.gui = g
 	// Функция, которая будет вызываться при обновлении интерфейса
 	g.SetManagerFunc(app.layout)
-	// Включить поддержку мыши
-	g.Mouse = false
+	// Поддержка мыши включается флагом, так как она отключает выделение текста в терминале
+	g.Mouse = app.mouse
 
 	// Цветовая схема GUI
 	g.FgColor = gocui.ColorDefault // поля всех окон и цвет текста
@@ -458,6 +649,7 @@ func runGoCui(mock bool) {
 	if err := app.layout(g); err != nil {
 		log.Panicln(err)
 	}
+	app.showHostTitle()
 
 	// Определяем переменные и массивы для покраски вывода
 	// Текущее имя хоста
@@ -546,6 +738,13 @@ func runGoCui(mock bool) {
 		app.loadFiles(app.selectPath)
 	}
 
+	// Containers
+	if v, err := g.View("docker"); err == nil {
+		_, viewHeight := v.Size()
+		app.maxVisibleDockerContainers = viewHeight
+	}
+	app.loadDockerContainer(app.selectContainerizationSystem)
+
 	// Устанавливаем фокус на окно с журналами по умолчанию
 	if _, err := g.SetCurrentView("filterList"); err != nil {
 		return
@@ -568,19 +767,41 @@ func runGoCui(mock bool) {
 }
 
 func main() {
+	// Режим наблюдения без интерфейса (lazyjournal watch -config rules.yml)
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		os.Exit(runWatch(os.Args[2:]))
+	}
 	runGoCui(false)
 }
 
 // Структура интерфейса окон GUI
 func (app *App) layout(g *gocui.Gui) error {
-	maxX, maxY := g.Size()                // получаем текущий размер интерфейса терминала (ширина, высота)
-	leftPanelWidth := maxX / 4            // ширина левой колонки
-	inputHeight := 3                      // высота поля ввода для фильтрации список
-	availableHeight := maxY - inputHeight // общая высота всех трех окон слева
-	panelHeight := availableHeight / 3    // высота каждого окна
+	maxX, maxY := g.Size() // получаем текущий размер интерфейса терминала (ширина, высота)
+	rects := app.layoutRects(maxX, maxY)
+
+	// Невидимое окно на границе панелей для изменения размера панели списков мышью (создается первым и находится под остальными окнами)
+	r := rects["panelBorder"]
+	if v, err := g.SetView("panelBorder", r.x0, r.y0, r.x1, r.y1, 0); err != nil {
+		if !errors.Is(err, gocui.ErrUnknownView) {
+			return err
+		}
+		v.Frame = false
+	}
+
+	// Невидимые окна для вывода неактивных вкладок при обновлении в фоне
+	for _, name := range []string{"logsTab", "scrollLogsTab"} {
+		r = rects[name]
+		if v, err := g.SetView(name, r.x0, r.y0, r.x1, r.y1, 0); err != nil {
+			if !errors.Is(err, gocui.ErrUnknownView) {
+				return err
+			}
+			v.Visible = false
+		}
+	}
 
 	// Поле ввода для фильтрации списков
-	if v, err := g.SetView("filterList", 0, 0, leftPanelWidth-1, inputHeight-1, 0); err != nil {
+	r = rects["filterList"]
+	if v, err := g.SetView("filterList", r.x0, r.y0, r.x1, r.y1, 0); err != nil {
 		if !errors.Is(err, gocui.ErrUnknownView) {
 			return err
 		}
@@ -594,7 +815,8 @@ func (app *App) layout(g *gocui.Gui) error {
 
 	// Окно для отображения списка доступных журналов (UNIT)
 	// Размеры окна: заголовок, отступ слева, отступ сверху, ширина, высота, 5-й параметр из форка для продолжение окна (2)
-	if v, err := g.SetView("services", 0, inputHeight, leftPanelWidth-1, inputHeight+panelHeight-1, 0); err != nil {
+	r = rects["services"]
+	if v, err := g.SetView("services", r.x0, r.y0, r.x1, r.y1, 0); err != nil {
 		if !errors.Is(err, gocui.ErrUnknownView) {
 			return err
 		}
@@ -609,7 +831,8 @@ func (app *App) layout(g *gocui.Gui) error {
 	}
 
 	// Окно для списка логов из файловой системы
-	if v, err := g.SetView("varLogs", 0, inputHeight+panelHeight, leftPanelWidth-1, inputHeight+2*panelHeight-1, 0); err != nil {
+	r = rects["varLogs"]
+	if v, err := g.SetView("varLogs", r.x0, r.y0, r.x1, r.y1, 0); err != nil {
 		if !errors.Is(err, gocui.ErrUnknownView) {
 			return err
 		}
@@ -622,8 +845,24 @@ func (app *App) layout(g *gocui.Gui) error {
 		app.updateLogsList()
 	}
 
+	// Окно для списка контейнеров
+	r = rects["docker"]
+	if v, err := g.SetView("docker", r.x0, r.y0, r.x1, r.y1, 0); err != nil {
+		if !errors.Is(err, gocui.ErrUnknownView) {
+			return err
+		}
+		v.Title = " < Docker containers (0) > "
+		v.Highlight = true
+		v.Wrap = false
+		v.Autoscroll = true
+		v.SelBgColor = gocui.ColorGreen
+		v.SelFgColor = gocui.ColorBlack
+		app.updateDockerContainerList()
+	}
+
 	// Окно ввода текста для фильтрации
-	if v, err := g.SetView("filter", leftPanelWidth+1, 0, maxX-1, 2, 0); err != nil {
+	r = rects["filter"]
+	if v, err := g.SetView("filter", r.x0, r.y0, r.x1, r.y1, 0); err != nil {
 		if !errors.Is(err, gocui.ErrUnknownView) {
 			return err
 		}
@@ -633,8 +872,42 @@ func (app *App) layout(g *gocui.Gui) error {
 		v.Wrap = true
 	}
 
+	// Панель вкладок открытых журналов (отображается после открытия первого журнала)
+	if r, ok := rects["tabs"]; ok {
+		if v, err := g.SetView("tabs", r.x0, r.y0, r.x1, r.y1, 0); err != nil {
+			if !errors.Is(err, gocui.ErrUnknownView) {
+				return err
+			}
+			v.Frame = false
+			v.Wrap = false
+			app.drawTabs()
+		}
+	} else if _, err := g.View("tabs"); err == nil {
+		if err := g.DeleteView("tabs"); err != nil {
+			return err
+		}
+	}
+
+	// Панель с гистограммой количества строк по времени над выводом журнала
+	if app.timelineMode {
+		r = rects["timeline"]
+		if v, err := g.SetView("timeline", r.x0, r.y0, r.x1, r.y1, 0); err != nil {
+			if !errors.Is(err, gocui.ErrUnknownView) {
+				return err
+			}
+			v.Title = "Timeline"
+			v.Wrap = false
+			v.Autoscroll = false
+		}
+	} else if _, err := g.View("timeline"); err == nil {
+		if err := g.DeleteView("timeline"); err != nil {
+			return err
+		}
+	}
+
 	// Интерфейс скролла в окне вывода лога (maxX-3 ширина окна - отступ слева)
-	if v, err := g.SetView("scrollLogs", maxX-3, 3, maxX-1, maxY-1, 0); err != nil {
+	r = rects["scrollLogs"]
+	if v, err := g.SetView("scrollLogs", r.x0, r.y0, r.x1, r.y1, 0); err != nil {
 		if !errors.Is(err, gocui.ErrUnknownView) {
 			return err
 		}
@@ -652,7 +925,8 @@ func (app *App) layout(g *gocui.Gui) error {
 	}
 
 	// Окно для вывода записей выбранного журнала (maxX-2 для отступа скролла и 8 для продолжения углов)
-	if v, err := g.SetView("logs", leftPanelWidth+1, 3, maxX-1-2, maxY-1, 8); err != nil {
+	r = rects["logs"]
+	if v, err := g.SetView("logs", r.x0, r.y0, r.x1, r.y1, 8); err != nil {
 		if !errors.Is(err, gocui.ErrUnknownView) {
 			return err
 		}
@@ -661,6 +935,43 @@ func (app *App) layout(g *gocui.Gui) error {
 		v.Autoscroll = false
 	}
 
+	// Второе окно вывода журнала для сравнения (со своим скроллом)
+	if app.splitPane != nil {
+		r = rects["scrollLogs2"]
+		if v, err := g.SetView("scrollLogs2", r.x0, r.y0, r.x1, r.y1, 0); err != nil {
+			if !errors.Is(err, gocui.ErrUnknownView) {
+				return err
+			}
+			v.Wrap = true
+			v.Autoscroll = false
+			v.FgColor = gocui.ColorGreen
+		}
+		r = rects["logs2"]
+		if v, err := g.SetView("logs2", r.x0, r.y0, r.x1, r.y1, 8); err != nil {
+			if !errors.Is(err, gocui.ErrUnknownView) {
+				return err
+			}
+			v.Title = "Logs"
+			v.Wrap = true
+			v.Autoscroll = false
+		}
+	} else {
+		for _, name := range []string{"logs2", "scrollLogs2"} {
+			if _, err := g.View(name); err == nil {
+				if err := g.DeleteView(name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Скрываем списки и поле фильтра в полноэкранном режиме вывода журнала
+	for _, name := range []string{"panelBorder", "filterList", "services", "varLogs", "docker", "filter"} {
+		if v, err := g.View(name); err == nil {
+			v.Visible = !app.zoomLogs
+		}
+	}
+
 	// Включение курсора в режиме фильтра и отключение в остальных окнах
 	currentView := g.CurrentView()
 	if currentView != nil && (currentView.Name() == "filter" || currentView.Name() == "filterList") {
@@ -668,6 +979,8 @@ func (app *App) layout(g *gocui.Gui) error {
 	} else {
 		g.Cursor = false
 	}
+	// Сохраняем позиции курсоров для восстановления после событий мыши
+	app.saveViewCursors(g)
 
 	return nil
 }
@@ -693,10 +1006,10 @@ func parseDateFromName(name string) time.Time {
 func (app *App) loadServices(journalName string) {
 	app.journals = nil
 	// Проверка, что в системе установлен/поддерживается утилита journalctl
-	checkJournald := exec.Command("journalctl", "--version")
+	checkJournald := app.command("journalctl", "--version")
 	// Проверяем на ошибки (очищаем список служб, отключаем курсор и выводим ошибку)
 	_, err := checkJournald.Output()
-	if err != nil && !app.testMode {
+	if err != nil && !app.noGui() {
 		vError, _ := app.gui.View("services")
 		vError.Clear()
 		app.journalListFrameColor = gocui.ColorRed
@@ -705,15 +1018,15 @@ func (app *App) loadServices(journalName string) {
 		fmt.Fprintln(vError, "\033[31msystemd-journald not supported\033[0m")
 		return
 	}
-	if err != nil && app.testMode {
+	if err != nil && app.noGui() {
 		log.Print("Error: systemd-journald not supported")
 	}
 	switch {
 	case journalName == "services":
 		// Получаем список всех юнитов в системе через systemctl в формате JSON
-		unitsList := exec.Command("systemctl", "list-units", "--all", "--plain", "--no-legend", "--no-pager", "--output=json") // "--type=service"
+		unitsList := app.command("systemctl", "list-units", "--all", "--plain", "--no-legend", "--no-pager", "--output=json") // "--type=service"
 		output, err := unitsList.Output()
-		if !app.testMode {
+		if !app.noGui() {
 			if err != nil {
 				vError, _ := app.gui.View("services")
 				vError.Clear()
@@ -730,7 +1043,7 @@ func (app *App) loadServices(journalName string) {
 			}
 			v.Highlight = true
 		}
-		if err != nil && app.testMode {
+		if err != nil && app.noGui() {
 			log.Print("Error: access denied in systemd via systemctl")
 		}
 		// Чтение данных в формате JSON
@@ -788,9 +1101,9 @@ func (app *App) loadServices(journalName string) {
 		}
 	case journalName == "kernel":
 		// Получаем список загрузок системы
-		bootCmd := exec.Command("journalctl", "--list-boots", "-o", "json")
+		bootCmd := app.command("journalctl", "--list-boots", "-o", "json")
 		bootOutput, err := bootCmd.Output()
-		if !app.testMode {
+		if !app.noGui() {
 			if err != nil {
 				vError, _ := app.gui.View("services")
 				vError.Clear()
@@ -808,7 +1121,7 @@ func (app *App) loadServices(journalName string) {
 				vError.Highlight = true
 			}
 		}
-		if err != nil && app.testMode {
+		if err != nil && app.noGui() {
 			log.Print("Error: getting boot information from journald")
 		}
 		// Структура для парсинга JSON
@@ -881,9 +1194,9 @@ func (app *App) loadServices(journalName string) {
 			return date1.After(date2)
 		})
 	default:
-		cmd := exec.Command("journalctl", "--no-pager", "-F", journalName)
+		cmd := app.command("journalctl", "--no-pager", "-F", journalName)
 		output, err := cmd.Output()
-		if !app.testMode {
+		if !app.noGui() {
 			if err != nil {
 				vError, _ := app.gui.View("services")
 				vError.Clear()
@@ -901,7 +1214,7 @@ func (app *App) loadServices(journalName string) {
 				vError.Highlight = true
 			}
 		}
-		if err != nil && app.testMode {
+		if err != nil && app.noGui() {
 			log.Print("Error: getting services from journald via journalctl")
 		}
 		// Создаем массив (хеш-таблица с доступом по ключу) для уникальных имен служб
@@ -922,7 +1235,7 @@ func (app *App) loadServices(journalName string) {
 			return app.journals[i].name < app.journals[j].name
 		})
 	}
-	if !app.testMode {
+	if !app.noGui() {
 		// Сохраняем неотфильтрованный список
 		app.journalsNotFilter = app.journals
 		// Применяем фильтр при загрузки и обновляем список служб в интерфейсе через updateServicesList() внутри функции
@@ -965,7 +1278,7 @@ func (app *App) loadWinEvents() {
 			boot_id: LogName,
 		})
 	}
-	if !app.testMode {
+	if !app.noGui() {
 		app.journalsNotFilter = app.journals
 		app.applyFilterList()
 	}
@@ -1092,13 +1405,16 @@ func (app *App) selectService(g *gocui.Gui, v *gocui.View) error {
 	if err != nil {
 		return err
 	}
+	// Открываем журнал в новой вкладке или переключаемся на вкладку, в которой он уже открыт
+	app.openTab("services", strings.TrimSpace(line))
+	// Фиксируем для ручного или автоматического обновления вывода журнала (до загрузки, что бы источник был известен при выводе)
+	app.lastWindow = "services"
+	app.lastSelected = strings.TrimSpace(line)
+	app.lastHost = app.host
 	// Загружаем журналы выбранной службы, обрезая пробелы в названии
 	app.loadJournalLogs(strings.TrimSpace(line), true)
 	// Включаем загрузку журнала (только при ручном выборе для Windows)
 	app.updateFile = true
-	// Фиксируем для ручного или автоматического обновления вывода журнала
-	app.lastWindow = "services"
-	app.lastSelected = strings.TrimSpace(line)
 	return nil
 }
 
@@ -1107,9 +1423,12 @@ func (app *App) selectService(g *gocui.Gui, v *gocui.View) error {
 func (app *App) loadJournalLogs(serviceName string, newUpdate bool) {
 	var output []byte
 	var err error
+	app.loadError = nil
 	selectUnits := app.selectUnits
 	if newUpdate {
 		app.lastSelectUnits = app.selectUnits
+		app.patternFilter = nil
+		app.logScrollX = 0
 	} else {
 		selectUnits = app.lastSelectUnits
 	}
@@ -1131,12 +1450,12 @@ func (app *App) loadJournalLogs(serviceName string, newUpdate bool) {
 			}
 		}
 		output = app.loadWinEventLog(eventName)
-		if len(output) == 0 && !app.testMode {
-			v, _ := app.gui.View("logs")
+		if len(output) == 0 && !app.noGui() {
+			v, _ := app.gui.View(app.logsViewName())
 			v.Clear()
 			return
 		}
-		if len(output) == 0 && app.testMode {
+		if len(output) == 0 && app.noGui() {
 			app.currentLogLines = []string{}
 			return
 		}
@@ -1156,16 +1475,16 @@ func (app *App) loadJournalLogs(serviceName string, newUpdate bool) {
 		} else {
 			boot_id = app.lastBootId
 		}
-		cmd := exec.Command("journalctl", "-k", "-b", boot_id, "--no-pager", "-n", app.logViewCount)
+		cmd := app.command("journalctl", "-k", "-b", boot_id, "--no-pager", "-n", app.logViewCount)
 		output, err = cmd.Output()
-		if err != nil && !app.testMode {
-			v, _ := app.gui.View("logs")
+		if err != nil && !app.noGui() {
+			v, _ := app.gui.View(app.logsViewName())
 			v.Clear()
 			fmt.Fprintln(v, "\033[31mError getting kernal logs:", err, "\033[0m")
 			return
 		}
-		if err != nil && app.testMode {
-			log.Print("Error: getting kernal logs. ", err)
+		if err != nil && app.noGui() {
+			app.logLoadError("getting kernal logs. ", err)
 		}
 		// Для юнитов systemd
 	default:
@@ -1174,21 +1493,21 @@ func (app *App) loadJournalLogs(serviceName string, newUpdate bool) {
 			var ansiEscape = regexp.MustCompile(`\s\(.+\)`)
 			serviceName = ansiEscape.ReplaceAllString(serviceName, "")
 		}
-		cmd := exec.Command("journalctl", "-u", serviceName, "--no-pager", "-n", app.logViewCount)
+		cmd := app.command("journalctl", "-u", serviceName, "--no-pager", "-n", app.logViewCount)
 		output, err = cmd.Output()
-		if err != nil && !app.testMode {
-			v, _ := app.gui.View("logs")
+		if err != nil && !app.noGui() {
+			v, _ := app.gui.View(app.logsViewName())
 			v.Clear()
 			fmt.Fprintln(v, "\033[31mError getting journald logs:", err, "\033[0m")
 			return
 		}
-		if err != nil && app.testMode {
-			log.Print("Error: getting journald logs.  ", err)
+		if err != nil && app.noGui() {
+			app.logLoadError("getting journald logs.  ", err)
 		}
 	}
 	// Сохраняем строки журнала в массив
 	app.currentLogLines = strings.Split(string(output), "\n")
-	if !app.testMode {
+	if !app.noGui() {
 		app.updateDelimiter(newUpdate)
 		// Очищаем поле ввода для фильтрации, что бы не применять фильтрацию к новому журналу
 		// app.filterText = ""
@@ -1255,19 +1574,25 @@ func (app *App) loadWinEventLog(eventName string) (output []byte) {
 
 func (app *App) loadFiles(logPath string) {
 	app.logfiles = nil // сбрасываем (очищаем) массив перед загрузкой новых журналов
+	// Файлы контейнера загружаются через его исполнитель команд независимо от текущего хоста
+	if logPath == "container" && app.host != app.containerFilesHost {
+		host := app.host
+		app.useHost(app.containerFilesHost)
+		defer app.useHost(host)
+	}
 	var output []byte
 	switch {
 	case logPath == "descriptor":
 		// n - имя файла (путь)
 		// c - имя команды (процесса)
-		cmd := exec.Command("lsof", "-Fn")
+		cmd := app.command("lsof", "-Fn")
 		// Подавить вывод ошибок при отсутствиее прав доступа (opendir: Permission denied)
 		cmd.Stderr = nil
 		output, _ = cmd.Output()
 		// Разбиваем вывод на строки
 		files := strings.Split(strings.TrimSpace(string(output)), "\n")
 		// Если список файлов пустой, возвращаем ошибку Permission denied
-		if !app.testMode {
+		if !app.noGui() {
 			if len(files) == 0 || (len(files) == 1 && files[0] == "") {
 				vError, _ := app.gui.View("varLogs")
 				vError.Clear()
@@ -1304,7 +1629,7 @@ func (app *App) loadFiles(logPath string) {
 		var cmd *exec.Cmd
 		// Загрузка системных журналов для MacOS
 		if app.getOS == "darwin" {
-			cmd = exec.Command(
+			cmd = app.command(
 				"find", logPath, "/Library/Logs",
 				"-type", "f",
 				"-name", "*.asl", "-o",
@@ -1319,7 +1644,7 @@ func (app *App) loadFiles(logPath string) {
 			)
 		} else {
 			// Загрузка системных журналов для Linux: все файлы, которые содержат log в расширение или названии (архивы включительно), а также расширение с цифрой (архивные) и pcap/pcapng
-			cmd = exec.Command(
+			cmd = app.command(
 				"find", logPath,
 				"-type", "f",
 				"-name", "*.log", "-o",
@@ -1337,7 +1662,7 @@ func (app *App) loadFiles(logPath string) {
 		// Преобразуем вывод команды в строку и делим на массив строк
 		files := strings.Split(strings.TrimSpace(string(output)), "\n")
 		// Если список файлов пустой, возвращаем ошибку Permission denied
-		if !app.testMode {
+		if !app.noGui() {
 			if len(files) == 0 || (len(files) == 1 && files[0] == "") {
 				vError, _ := app.gui.View("varLogs")
 				vError.Clear()
@@ -1391,7 +1716,7 @@ func (app *App) loadFiles(logPath string) {
 		}
 	case logPath == "/opt/":
 		var cmd *exec.Cmd
-		cmd = exec.Command(
+		cmd = app.command(
 			"find", logPath,
 			"-type", "f",
 			"-name", "*.log", "-o",
@@ -1399,7 +1724,7 @@ func (app *App) loadFiles(logPath string) {
 		)
 		output, _ = cmd.Output()
 		files := strings.Split(strings.TrimSpace(string(output)), "\n")
-		if !app.testMode {
+		if !app.noGui() {
 			if len(files) == 0 || (len(files) == 1 && files[0] == "") {
 				vError, _ := app.gui.View("varLogs")
 				vError.Clear()
@@ -1423,13 +1748,57 @@ func (app *App) loadFiles(logPath string) {
 				log.Print("Error: files not found in /opt/")
 			}
 		}
+	case logPath == "container":
+		// Поиск по всей файловой системе контейнера, кроме виртуальных файловых систем
+		// В /var/log выводятся все файлы (syslog и messages без расширения .log)
+		cmd := app.command(
+			"find", "/",
+			"(",
+			"-path", "/proc", "-o",
+			"-path", "/sys", "-o",
+			"-path", "/dev",
+			")",
+			"-prune", "-o",
+			"-type", "f",
+			"(",
+			"-name", "*.log", "-o",
+			"-name", "*.log.*", "-o",
+			"-path", "/var/log/*",
+			")",
+			"-print",
+		)
+		cmd.Stderr = nil
+		output, _ = cmd.Output()
+		files := strings.Split(strings.TrimSpace(string(output)), "\n")
+		if !app.noGui() {
+			if len(files) == 0 || (len(files) == 1 && files[0] == "") {
+				vError, _ := app.gui.View("varLogs")
+				vError.Clear()
+				app.fileSystemFrameColor = gocui.ColorRed
+				vError.FrameColor = app.fileSystemFrameColor
+				vError.Highlight = false
+				fmt.Fprintln(vError, "\033[31mFiles not found in container\033[0m")
+				return
+			} else {
+				vError, _ := app.gui.View("varLogs")
+				app.fileSystemFrameColor = gocui.ColorDefault
+				if vError.FrameColor != gocui.ColorDefault {
+					vError.FrameColor = gocui.ColorGreen
+				}
+				vError.Highlight = true
+			}
+		} else {
+			if len(files) == 0 || (len(files) == 1 && files[0] == "") {
+				log.Print("Error: files not found in container")
+			}
+		}
 	default:
 		// Домашние каталоги пользователей: /home/ для Linux и /Users/ для MacOS
 		if app.getOS == "darwin" {
 			logPath = "/Users/"
 		}
 		// Ищем файлы с помощью системной утилиты find
-		cmd := exec.Command(
+		cmd := app.command(
 			"find", logPath,
 			"-type", "d",
 			"(",
@@ -1453,7 +1822,7 @@ func (app *App) loadFiles(logPath string) {
 		)
 		output, _ = cmd.Output()
 		files := strings.Split(strings.TrimSpace(string(output)), "\n")
-		if !app.testMode {
+		if !app.noGui() {
 			if len(files) == 0 || (len(files) == 1 && files[0] == "") {
 				vError, _ := app.gui.View("varLogs")
 				vError.Clear()
@@ -1474,7 +1843,7 @@ func (app *App) loadFiles(logPath string) {
 			}
 		}
 		// Получаем содержимое файлов из домашнего каталога пользователя root
-		cmdRootDir := exec.Command(
+		cmdRootDir := app.command(
 			"find", "/root/",
 			"-type", "f",
 			"-name", "*.log", "-o",
@@ -1488,7 +1857,7 @@ func (app *App) loadFiles(logPath string) {
 		if err == nil {
 			output = append(output, outputRootDir...)
 		}
-		if app.fileSystemFrameColor == gocui.ColorRed && !app.testMode {
+		if app.fileSystemFrameColor == gocui.ColorRed && !app.noGui() {
 			vError, _ := app.gui.View("varLogs")
 			app.fileSystemFrameColor = gocui.ColorDefault
 			if vError.FrameColor != gocui.ColorDefault {
@@ -1498,6 +1867,8 @@ func (app *App) loadFiles(logPath string) {
 		}
 	}
 	serviceMap := make(map[string]bool)
+	// Получаем размер и дату изменения всех найденных файлов
+	fileStats := app.statFiles(strings.Split(strings.TrimSpace(string(output)), "\n"))
 	scanner := bufio.NewScanner(strings.NewReader(string(output)))
 	for scanner.Scan() {
 		// Получаем строку полного пути
@@ -1525,18 +1896,18 @@ func (app *App) loadFiles(logPath string) {
 		}
 		// Получаем информацию о файле
 		// cmd := exec.Command("bash", "-c", "stat --format='%y' /var/log/apache2/access.log | awk '{print $1}' | awk -F- '{print $3\".\"$2\".\"$1}'")
-		fileInfo, err := os.Stat(logFullPath)
-		if err != nil {
+		fileInfo, ok := fileStats[logFullPath]
+		if !ok {
 			// Пропускаем файл, если к нему нет доступа (актуально для статических файлов из logPath)
 			continue
 		}
 		// Проверяем, что файл не пустой
-		if fileInfo.Size() == 0 {
+		if fileInfo.size == 0 {
 			// Пропускаем пустой файл
 			continue
 		}
 		// Получаем дату изменения
-		modTime := fileInfo.ModTime()
+		modTime := fileInfo.modTime
 		// Форматирование даты в формат DD.MM.YYYY
 		formattedDate := modTime.Format("02.01.2006")
 		// Проверяем, что полного пути до файла еще нет в списке
@@ -1545,7 +1916,7 @@ func (app *App) loadFiles(logPath string) {
 			serviceMap[logFullPath] = true
 			// Получаем имя процесса для файла дескриптора
 			if logPath == "descriptor" {
-				cmd := exec.Command("lsof", "-Fc", logFullPath)
+				cmd := app.command("lsof", "-Fc", logFullPath)
 				cmd.Stderr = nil
 				outputLsof, _ := cmd.Output()
 				processLines := strings.Split(strings.TrimSpace(string(outputLsof)), "\n")
@@ -1576,7 +1947,7 @@ func (app *App) loadFiles(logPath string) {
 		// Сортировка в обратном порядке
 		return dateI.After(dateJ)
 	})
-	if !app.testMode {
+	if !app.noGui() {
 		app.logfilesNotFilter = app.logfiles
 		app.applyFilterList()
 	}
@@ -1643,7 +2014,7 @@ func (app *App) loadWinFiles(logPath string) {
 	wg.Wait()
 	// Объединяем все пути в одну строку, разделенную символом новой строки
 	output := strings.Join(files, "\n")
-	if !app.testMode {
+	if !app.noGui() {
 		// Если список файлов пустой, возвращаем ошибку
 		if len(files) == 0 || (len(files) == 1 && files[0] == "") {
 			vError, _ := app.gui.View("varLogs")
@@ -1707,7 +2078,7 @@ func (app *App) loadWinFiles(logPath string) {
 		dateJ, _ := time.Parse(layout, extractDate(app.logfiles[j].name))
 		return dateI.After(dateJ)
 	})
-	if !app.testMode {
+	if !app.noGui() {
 		app.logfilesNotFilter = app.logfiles
 		app.applyFilterList()
 	}
@@ -1811,18 +2182,38 @@ func (app *App) selectFile(g *gocui.Gui, v *gocui.View) error {
 	if err != nil {
 		return err
 	}
-	app.loadFileLogs(strings.TrimSpace(line), true)
+	// Файл контейнера открывается с хостом контейнера, что бы журнал обновлялся через его исполнитель команд
+	if app.selectPath == "container" && app.host != app.containerFilesHost {
+		host := app.host
+		app.useHost(app.containerFilesHost)
+		defer app.useHost(host)
+	}
+	app.openTab("varLogs", strings.TrimSpace(line))
 	app.lastWindow = "varLogs"
 	app.lastSelected = strings.TrimSpace(line)
+	app.lastHost = app.host
+	app.loadFileLogs(strings.TrimSpace(line), true)
 	return nil
 }
 
+// Функция для проверки, что приложение работает без интерфейса (тесты и режим наблюдения)
+func (app *App) noGui() bool {
+	return app.testMode || app.headless
+}
+
+// Функция для сохранения ошибки загрузки журнала без интерфейса (в режиме наблюдения выводится в stderr)
+func (app *App) logLoadError(args ...any) {
+	app.loadError = errors.New(fmt.Sprint(args...))
+	log.Print("Error: ", app.loadError)
+}
+
 // Функция для чтения файла
 func (app *App) loadFileLogs(logName string, newUpdate bool) {
 	// В параметре logName имя файла при выборе возвращяется без символов покраски
 	// Получаем путь из массива по имени
 	var logFullPath string
 	var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	app.loadError = nil
 	for _, logfile := range app.logfiles {
 		// Удаляем покраску из имени файла в сохраненном массиве
 		logFileName := ansiEscape.ReplaceAllString(logfile.name, "")
@@ -1834,25 +2225,29 @@ func (app *App) loadFileLogs(logName string, newUpdate bool) {
 	}
 	if newUpdate {
 		app.lastLogPath = logFullPath
+		app.patternFilter = nil
+		app.logScrollX = 0
 		// Фиксируем новую дату изменения и размер для выбранного файла
-		fileInfo, err := os.Stat(logFullPath)
-		if err != nil {
+		fileInfo, ok := app.statFile(logFullPath)
+		if !ok {
+			app.logLoadError("file not found or access denied: ", logFullPath)
 			return
 		}
-		fileModTime := fileInfo.ModTime()
-		fileSize := fileInfo.Size()
+		fileModTime := fileInfo.modTime
+		fileSize := fileInfo.size
 		app.lastDateUpdateFile = fileModTime
 		app.lastSizeFile = fileSize
 		app.updateFile = true
 	} else {
 		logFullPath = app.lastLogPath
 		// Проверяем дату изменения
-		fileInfo, err := os.Stat(logFullPath)
-		if err != nil {
+		fileInfo, ok := app.statFile(logFullPath)
+		if !ok {
+			app.logLoadError("file not found or access denied: ", logFullPath)
 			return
 		}
-		fileModTime := fileInfo.ModTime()
-		fileSize := fileInfo.Size()
+		fileModTime := fileInfo.modTime
+		fileSize := fileInfo.size
 		// Обновлять файл в горутине, только если есть изменения (проверяем дату модификации и размер)
 		if fileModTime != app.lastDateUpdateFile || fileSize != app.lastSizeFile {
 			app.lastDateUpdateFile = fileModTime
@@ -1867,14 +2262,14 @@ func (app *App) loadFileLogs(logName string, newUpdate bool) {
 		// Читаем логи в системе Windows
 		if app.getOS == "windows" {
 			decodedOutput, stringErrors := app.loadWinFileLog(logFullPath)
-			if stringErrors != "nil" && !app.testMode {
-				v, _ := app.gui.View("logs")
+			if stringErrors != "nil" && !app.noGui() {
+				v, _ := app.gui.View(app.logsViewName())
 				v.Clear()
 				fmt.Fprintln(v, "\033[31mError", stringErrors, "\033[0m")
 				return
 			}
-			if stringErrors != "nil" && app.testMode {
-				log.Print("Error: ", stringErrors)
+			if stringErrors != "nil" && app.noGui() {
+				app.logLoadError(stringErrors)
 			}
 			app.currentLogLines = strings.Split(string(decodedOutput), "\n")
 		} else {
@@ -1882,89 +2277,92 @@ func (app *App) loadFileLogs(logName string, newUpdate bool) {
 			switch {
 			// Читаем файлы в формате ASL (Apple System Log)
 			case strings.HasSuffix(logFullPath, "asl"):
-				cmd := exec.Command("syslog", "-f", logFullPath)
+				cmd := app.command("syslog", "-f", logFullPath)
 				output, err := cmd.Output()
-				if err != nil && !app.testMode {
-					v, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					v, _ := app.gui.View(app.logsViewName())
 					v.Clear()
 					fmt.Fprintln(v, " \033[31mError reading log using syslog tool in ASL (Apple System Log) format.\n", err, "\033[0m")
 					return
 				}
-				if err != nil && app.testMode {
-					log.Print("Error: reading log using syslog tool in ASL (Apple System Log) format. ", err)
+				if err != nil && app.noGui() {
+					app.logLoadError("reading log using syslog tool in ASL (Apple System Log) format. ", err)
 				}
 				app.currentLogLines = strings.Split(string(output), "\n")
 			// Читаем журналы Packet Capture в формате pcap/pcapng
 			case strings.HasSuffix(logFullPath, "pcap") || strings.HasSuffix(logFullPath, "pcapng"):
-				cmd := exec.Command("tcpdump", "-n", "-r", logFullPath)
+				cmd := app.command("tcpdump", "-n", "-r", logFullPath)
 				output, err := cmd.Output()
-				if err != nil && !app.testMode {
-					v, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					v, _ := app.gui.View(app.logsViewName())
 					v.Clear()
 					fmt.Fprintln(v, " \033[31mError reading log using tcpdump tool.\n", err, "\033[0m")
 					return
 				}
-				if err != nil && app.testMode {
-					log.Print("Error: reading log using tcpdump tool. ", err)
+				if err != nil && app.noGui() {
+					app.logLoadError("reading log using tcpdump tool. ", err)
 				}
 				app.currentLogLines = strings.Split(string(output), "\n")
 			// Packet Filter (PF) Firewall OpenBSD
 			case strings.HasSuffix(logFullPath, "pflog"):
-				cmd := exec.Command("tcpdump", "-e", "-n", "-r", logFullPath)
+				cmd := app.command("tcpdump", "-e", "-n", "-r", logFullPath)
 				output, err := cmd.Output()
-				if err != nil && !app.testMode {
-					v, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					v, _ := app.gui.View(app.logsViewName())
 					v.Clear()
 					fmt.Fprintln(v, " \033[31mError reading log using tcpdump tool.\n", err, "\033[0m")
 					return
 				}
+				if err != nil && app.noGui() {
+					app.logLoadError("reading log using tcpdump tool. ", err)
+				}
 				app.currentLogLines = strings.Split(string(output), "\n")
 			// Читаем архивные логи в формате pcap/pcapng (MacOS)
 			case strings.HasSuffix(logFullPath, "pcap.gz") || strings.HasSuffix(logFullPath, "pcapng.gz"):
 				var unpacker string = "gzip"
 				// Создаем временный файл
 				tmpFile, err := os.CreateTemp("", "temp-*.pcap")
-				if err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError create temp file.\n", err, "\033[0m")
 					return
 				}
 				// Удаляем временный файл после обработки
 				defer os.Remove(tmpFile.Name())
-				cmdUnzip := exec.Command(unpacker, "-dc", logFullPath)
+				cmdUnzip := app.command(unpacker, "-dc", logFullPath)
 				cmdUnzip.Stdout = tmpFile
-				if err := cmdUnzip.Start(); err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err := cmdUnzip.Start(); err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError starting", unpacker, "tool.\n", err, "\033[0m")
 					return
 				}
-				if err := cmdUnzip.Wait(); err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err := cmdUnzip.Wait(); err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError decompressing file with", unpacker, "tool.\n", err, "\033[0m")
 					return
 				}
 				// Закрываем временный файл, чтобы tcpdump мог его открыть
-				if err := tmpFile.Close(); err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err := tmpFile.Close(); err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError closing temp file.\n", err, "\033[0m")
 					return
 				}
-				// Создаем команду для tcpdump
+				// Создаем команду для tcpdump (временный файл находится на локальном хосте)
 				cmdTcpdump := exec.Command("tcpdump", "-n", "-r", tmpFile.Name())
 				tcpdumpOut, err := cmdTcpdump.StdoutPipe()
-				if err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError creating stdout pipe for tcpdump.\n", err, "\033[0m")
 					return
 				}
 				// Запускаем tcpdump
-				if err := cmdTcpdump.Start(); err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err := cmdTcpdump.Start(); err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError starting tcpdump.\n", err, "\033[0m")
 					return
@@ -1975,15 +2373,15 @@ func (app *App) loadFileLogs(logName string, newUpdate bool) {
 				for scanner.Scan() {
 					lines = append(lines, scanner.Text())
 				}
-				if err := scanner.Err(); err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err := scanner.Err(); err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError reading output from tcpdump.\n", err, "\033[0m")
 					return
 				}
 				// Ожидаем завершения tcpdump
-				if err := cmdTcpdump.Wait(); err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err := cmdTcpdump.Wait(); err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError finishing tcpdump.\n", err, "\033[0m")
 					return
@@ -2000,11 +2398,11 @@ func (app *App) loadFileLogs(logName string, newUpdate bool) {
 				case strings.HasSuffix(logFullPath, ".bz2"):
 					unpacker = "bzip2"
 				}
-				cmdUnzip := exec.Command(unpacker, "-dc", logFullPath)
-				cmdTail := exec.Command("tail", "-n", app.logViewCount)
+				cmdUnzip := app.command(unpacker, "-dc", logFullPath)
+				cmdTail := app.command("tail", "-n", app.logViewCount)
 				pipe, err := cmdUnzip.StdoutPipe()
-				if err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError creating pipe for", unpacker, "tool.\n", err, "\033[0m")
 					return
@@ -2012,42 +2410,42 @@ func (app *App) loadFileLogs(logName string, newUpdate bool) {
 				// Стандартный вывод программы передаем в stdin tail
 				cmdTail.Stdin = pipe
 				out, err := cmdTail.StdoutPipe()
-				if err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError creating stdout pipe for tail.\n", err, "\033[0m")
 					return
 				}
 				// Запуск команд
-				if err := cmdUnzip.Start(); err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err := cmdUnzip.Start(); err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError starting", unpacker, "tool.\n", err, "\033[0m")
 					return
 				}
-				if err := cmdTail.Start(); err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err := cmdTail.Start(); err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError starting tail from", unpacker, "stdout.\n", err, "\033[0m")
 					return
 				}
 				// Чтение вывода
 				output, err := io.ReadAll(out)
-				if err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError reading output from tail.\n", err, "\033[0m")
 					return
 				}
 				// Ожидание завершения команд
-				if err := cmdUnzip.Wait(); err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err := cmdUnzip.Wait(); err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError reading archive log using", unpacker, "tool.\n", err, "\033[0m")
 					return
 				}
-				if err := cmdTail.Wait(); err != nil && !app.testMode {
-					vError, _ := app.gui.View("logs")
+				if err := cmdTail.Wait(); err != nil && !app.noGui() {
+					vError, _ := app.gui.View(app.logsViewName())
 					vError.Clear()
 					fmt.Fprintln(vError, " \033[31mError reading log using tail tool.\n", err, "\033[0m")
 					return
@@ -2056,14 +2454,17 @@ func (app *App) loadFileLogs(logName string, newUpdate bool) {
 				app.currentLogLines = strings.Split(string(output), "\n")
 			// Читаем бинарные файлы с помощью last для wtmp, а также utmp (OpenBSD) и utx.log (FreeBSD)
 			case strings.Contains(logFullPath, "wtmp") || strings.Contains(logFullPath, "utmp") || strings.Contains(logFullPath, "utx.log"):
-				cmd := exec.Command("last", "-f", logFullPath)
+				cmd := app.command("last", "-f", logFullPath)
 				output, err := cmd.Output()
-				if err != nil && !app.testMode {
-					v, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					v, _ := app.gui.View(app.logsViewName())
 					v.Clear()
 					fmt.Fprintln(v, " \033[31mError reading log using last tool.\n", err, "\033[0m")
 					return
 				}
+				if err != nil && app.noGui() {
+					app.logLoadError("reading log using last tool. ", err)
+				}
 				// Разбиваем вывод на строки
 				lines := strings.Split(string(output), "\n")
 				var filteredLines []string
@@ -2081,14 +2482,17 @@ func (app *App) loadFileLogs(logName string, newUpdate bool) {
 				app.currentLogLines = filteredLines
 			// lastb for btmp
 			case strings.Contains(logFullPath, "btmp"):
-				cmd := exec.Command("lastb", "-f", logFullPath)
+				cmd := app.command("lastb", "-f", logFullPath)
 				output, err := cmd.Output()
-				if err != nil && !app.testMode {
-					v, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					v, _ := app.gui.View(app.logsViewName())
 					v.Clear()
 					fmt.Fprintln(v, " \033[31mError reading log using lastb tool.\n", err, "\033[0m")
 					return
 				}
+				if err != nil && app.noGui() {
+					app.logLoadError("reading log using lastb tool. ", err)
+				}
 				lines := strings.Split(string(output), "\n")
 				var filteredLines []string
 				for _, line := range lines {
@@ -2103,39 +2507,48 @@ func (app *App) loadFileLogs(logName string, newUpdate bool) {
 				app.currentLogLines = filteredLines
 			// Выводим содержимое из команды lastlog
 			case strings.HasSuffix(logFullPath, "lastlog"):
-				cmd := exec.Command("lastlog")
+				cmd := app.command("lastlog")
 				output, err := cmd.Output()
-				if err != nil && !app.testMode {
-					v, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					v, _ := app.gui.View(app.logsViewName())
 					v.Clear()
 					fmt.Fprintln(v, " \033[31mError reading log using lastlog tool.\n", err, "\033[0m")
 					return
 				}
+				if err != nil && app.noGui() {
+					app.logLoadError("reading log using lastlog tool. ", err)
+				}
 				app.currentLogLines = strings.Split(string(output), "\n")
 			// lastlogin for FreeBSD
 			case strings.HasSuffix(logFullPath, "lastlogin"):
-				cmd := exec.Command("lastlogin")
+				cmd := app.command("lastlogin")
 				output, err := cmd.Output()
-				if err != nil && !app.testMode {
-					v, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					v, _ := app.gui.View(app.logsViewName())
 					v.Clear()
 					fmt.Fprintln(v, " \033[31mError reading log using lastlogin tool.\n", err, "\033[0m")
 					return
 				}
+				if err != nil && app.noGui() {
+					app.logLoadError("reading log using lastlogin tool. ", err)
+				}
 				app.currentLogLines = strings.Split(string(output), "\n")
 			default:
-				cmd := exec.Command("tail", "-n", app.logViewCount, logFullPath)
+				cmd := app.command("tail", "-n", app.logViewCount, logFullPath)
 				output, err := cmd.Output()
-				if err != nil && !app.testMode {
-					v, _ := app.gui.View("logs")
+				if err != nil && !app.noGui() {
+					v, _ := app.gui.View(app.logsViewName())
 					v.Clear()
 					fmt.Fprintln(v, " \033[31mError reading log using tail tool.\n", err, "\033[0m")
 					return
 				}
+				if err != nil && app.noGui() {
+					app.logLoadError("reading log using tail tool. ", err)
+				}
 				app.currentLogLines = strings.Split(string(output), "\n")
 			}
 		}
-		if !app.testMode {
+		if !app.noGui() {
 			app.updateDelimiter(newUpdate)
 			app.applyFilter(false)
 		}
@@ -2225,72 +2638,682 @@ func (app *App) loadWinFileLog(filePath string) (output []byte, stringErrors str
 	return decodedOutput, "nil"
 }
 
-// ---------------------------------------- Filter ----------------------------------------
+// ---------------------------------------- Containers ----------------------------------------
 
-// Редактор обработки ввода текста для фильтрации
-func (app *App) createFilterEditor(window string) gocui.Editor {
-	return gocui.EditorFunc(func(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
-		switch {
-		// добавляем символ в поле ввода
-		case ch != 0 && mod == 0:
-			v.EditWrite(ch)
-		// добавляем пробел
-		case key == gocui.KeySpace:
-			v.EditWrite(' ')
-		// удаляем символ слева от курсора
-		case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
-			v.EditDelete(true)
-		// Удаляем символ справа от курсора
-		case key == gocui.KeyDelete:
-			v.EditDelete(false)
-		// Перемещение курсора влево
-		case key == gocui.KeyArrowLeft:
-			v.MoveCursor(-1, 0) // удалить 3-й булевой параметр для форка
-		// Перемещение курсора вправо
-		case key == gocui.KeyArrowRight:
-			v.MoveCursor(1, 0)
+// Функция для загрузки списка контейнеров Docker/Podman или подов Kubernetes
+func (app *App) loadDockerContainer(containerizationSystem string) {
+	app.dockerContainers = nil
+	// Проверка, что в системе установлен клиент системы контейнеризации
+	checkArgs := []string{"--version"}
+	if containerizationSystem == "kubectl" {
+		checkArgs = []string{"version", "--client"}
+	}
+	_, err := app.command(containerizationSystem, checkArgs...).Output()
+	if err != nil && !app.noGui() {
+		vError, _ := app.gui.View("docker")
+		vError.Clear()
+		app.dockerFrameColor = gocui.ColorRed
+		vError.FrameColor = app.dockerFrameColor
+		vError.Highlight = false
+		fmt.Fprintln(vError, "\033[31m"+containerizationSystem+" not installed (environment not found)\033[0m")
+		return
+	}
+	if err != nil && app.noGui() {
+		log.Print("Error: ", containerizationSystem, " not installed (environment not found)")
+	}
+	var containers []DockerContainers
+	if containerizationSystem == "kubectl" {
+		containers, err = app.loadKubeObjects()
+	} else {
+		// Идентификатор, имя и состояние в одной строке
+		var output []byte
+		output, err = app.command(containerizationSystem, "ps", "-a", "--format", "{{.ID}} {{.Names}} {{.State}}").Output()
+		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+			// Запущенные контейнеры выделяются зеленым, остальные красным
+			nameColor := "\033[31m"
+			if strings.EqualFold(fields[2], "running") {
+				nameColor = "\033[32m"
+			}
+			containers = append(containers, DockerContainers{
+				name: nameColor + fields[1] + "\033[0m",
+				id:   fields[0],
+			})
 		}
-		if window == "logs" {
-			// Обновляем текст в буфере
-			app.filterText = strings.TrimSpace(v.Buffer())
-			// Применяем функцию фильтрации к выводу записей журнала
-			app.applyFilter(true)
-		} else if window == "lists" {
-			app.filterListText = strings.TrimSpace(v.Buffer())
-			app.applyFilterList()
+	}
+	if !app.noGui() {
+		if err != nil {
+			vError, _ := app.gui.View("docker")
+			vError.Clear()
+			app.dockerFrameColor = gocui.ColorRed
+			vError.FrameColor = app.dockerFrameColor
+			vError.Highlight = false
+			fmt.Fprintln(vError, "\033[31mAccess denied or "+containerizationSystem+" is not running\033[0m")
+			return
 		}
-	})
+		v, _ := app.gui.View("docker")
+		app.dockerFrameColor = gocui.ColorDefault
+		if v.FrameColor != gocui.ColorDefault {
+			v.FrameColor = gocui.ColorGreen
+		}
+		v.Highlight = true
+	}
+	if err != nil && app.noGui() {
+		log.Print("Error: access denied or ", containerizationSystem, " is not running")
+	}
+	app.dockerContainers = containers
+	if !app.noGui() {
+		app.dockerContainersNotFilter = app.dockerContainers
+		app.applyFilterList()
+	}
 }
 
-// Функция для фильтрации всех списоков журналов
-func (app *App) applyFilterList() {
-	filter := strings.ToLower(app.filterListText)
-	// Временные массивы для отфильтрованных журналов
-	var filteredJournals []Journal
-	var filteredLogFiles []Logfile
-	for _, j := range app.journalsNotFilter {
-		if strings.Contains(strings.ToLower(j.name), filter) {
-			filteredJournals = append(filteredJournals, j)
-		}
+func (app *App) updateDockerContainerList() {
+	v, err := app.gui.View("docker")
+	if err != nil {
+		return
 	}
-	for _, j := range app.logfilesNotFilter {
-		if strings.Contains(strings.ToLower(j.name), filter) {
-			filteredLogFiles = append(filteredLogFiles, j)
-		}
+	v.Clear()
+	visibleEnd := app.startDockerContainers + app.maxVisibleDockerContainers
+	if visibleEnd > len(app.dockerContainers) {
+		visibleEnd = len(app.dockerContainers)
 	}
-	// Сбрасываем индексы выбранного журнала для правильного позиционирования
-	app.selectedJournal = 0
-	app.selectedFile = 0
-	app.startServices = 0
+	for i := app.startDockerContainers; i < visibleEnd; i++ {
+		fmt.Fprintln(v, app.dockerContainers[i].name)
+	}
+}
+
+func (app *App) nextDockerContainer(v *gocui.View, step int) error {
+	_, viewHeight := v.Size()
+	app.maxVisibleDockerContainers = viewHeight
+	if len(app.dockerContainers) == 0 {
+		return nil
+	}
+	if app.selectedDockerContainer < len(app.dockerContainers)-1 {
+		app.selectedDockerContainer += step
+		if app.selectedDockerContainer >= len(app.dockerContainers) {
+			app.selectedDockerContainer = len(app.dockerContainers) - 1
+		}
+		if app.selectedDockerContainer >= app.startDockerContainers+app.maxVisibleDockerContainers {
+			app.startDockerContainers += step
+			if app.startDockerContainers > len(app.dockerContainers)-app.maxVisibleDockerContainers {
+				app.startDockerContainers = len(app.dockerContainers) - app.maxVisibleDockerContainers
+			}
+			app.updateDockerContainerList()
+		}
+		if app.selectedDockerContainer < app.startDockerContainers+app.maxVisibleDockerContainers {
+			return app.selectDockerByIndex(app.selectedDockerContainer - app.startDockerContainers)
+		}
+	}
+	return nil
+}
+
+func (app *App) prevDockerContainer(v *gocui.View, step int) error {
+	_, viewHeight := v.Size()
+	app.maxVisibleDockerContainers = viewHeight
+	if len(app.dockerContainers) == 0 {
+		return nil
+	}
+	if app.selectedDockerContainer > 0 {
+		app.selectedDockerContainer -= step
+		if app.selectedDockerContainer < 0 {
+			app.selectedDockerContainer = 0
+		}
+		if app.selectedDockerContainer < app.startDockerContainers {
+			app.startDockerContainers -= step
+			if app.startDockerContainers < 0 {
+				app.startDockerContainers = 0
+			}
+			app.updateDockerContainerList()
+		}
+		if app.selectedDockerContainer >= app.startDockerContainers {
+			return app.selectDockerByIndex(app.selectedDockerContainer - app.startDockerContainers)
+		}
+	}
+	return nil
+}
+
+func (app *App) selectDockerByIndex(index int) error {
+	v, err := app.gui.View("docker")
+	if err != nil {
+		return err
+	}
+	// Обновляем счетчик в заголовке
+	re := regexp.MustCompile(`\s\(.+\) >`)
+	updateTitle := " (0) >"
+	if len(app.dockerContainers) != 0 {
+		updateTitle = " (" + strconv.Itoa(app.selectedDockerContainer+1) + "/" + strconv.Itoa(len(app.dockerContainers)) + ") >"
+	}
+	v.Title = re.ReplaceAllString(v.Title, updateTitle)
+	if err := v.SetCursor(0, index); err != nil {
+		return nil
+	}
+	return nil
+}
+
+func (app *App) selectDocker(g *gocui.Gui, v *gocui.View) error {
+	if v == nil || len(app.dockerContainers) == 0 {
+		return nil
+	}
+	_, cy := v.Cursor()
+	line, err := v.Line(cy)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSpace(line)
+	// В списке Kubernetes журнал открывается только для контейнера пода
+	if app.selectContainerizationSystem == "kubectl" {
+		if name = app.selectKubeObject(name); name == "" {
+			return nil
+		}
+	}
+	app.openTab("docker", name)
+	app.lastWindow = "docker"
+	app.lastSelected = name
+	app.lastHost = app.host
+	app.loadDockerLogs(name, true)
+	return nil
+}
+
+// Функция для загрузки журнала контейнера (stdout и stderr) или всех контейнеров пода с временными метками
+func (app *App) loadDockerLogs(containerName string, newUpdate bool) {
+	containerizationSystem := app.selectContainerizationSystem
+	// Для контейнера пода Kubernetes передается путь namespace/pod/container
+	containerId := containerName
+	app.loadError = nil
+	if newUpdate {
+		// Получаем идентификатор по имени контейнера без покраски
+		for _, dockerContainer := range app.dockerContainers {
+			if removeANSI(dockerContainer.name) == containerName {
+				containerId = dockerContainer.id
+				break
+			}
+		}
+		app.lastContainerizationSystem = containerizationSystem
+		app.lastContainerId = containerId
+		app.previousInstance = false
+		app.patternFilter = nil
+		app.logScrollX = 0
+	} else {
+		containerizationSystem = app.lastContainerizationSystem
+		containerId = app.lastContainerId
+	}
+	var output []byte
+	var err error
+	if containerizationSystem == "kubectl" {
+		output, err = app.streamPodLogs(containerId)
+	} else {
+		output, err = app.command(containerizationSystem, "logs", "--timestamps", "--tail", app.logViewCount, containerId).CombinedOutput()
+	}
+	if err != nil && !app.noGui() {
+		v, _ := app.gui.View(app.logsViewName())
+		v.Clear()
+		// Выводим сообщение клиента (например, отсутствие предыдущего экземпляра контейнера)
+		if message := strings.TrimSpace(string(output)); message != "" {
+			err = errors.New(message)
+		}
+		fmt.Fprintln(v, "\033[31mError getting container logs:", err, "\033[0m")
+		return
+	}
+	if err != nil && app.noGui() {
+		if message := strings.TrimSpace(string(output)); message != "" {
+			err = errors.New(message)
+		}
+		app.logLoadError("getting container logs. ", err)
+	}
+	app.currentLogLines = strings.Split(string(output), "\n")
+	if !app.noGui() {
+		app.updateDelimiter(newUpdate)
+		app.applyFilter(false)
+	}
+}
+
+// Функция для вывода списка файлов журналов выбранного контейнера или пода в окне файловой системы
+// Команды выполняются через docker/podman/kubectl exec, файлы открываются и обновляются так же, как файлы хоста
+func (app *App) browseContainerFiles(g *gocui.Gui, v *gocui.View) error {
+	if v == nil || len(app.dockerContainers) == 0 || app.getOS == "windows" {
+		return nil
+	}
+	_, cy := v.Cursor()
+	line, err := v.Line(cy)
+	if err != nil {
+		return err
+	}
+	selectedVarLog, err := g.View("varLogs")
+	if err != nil {
+		return err
+	}
+	showError := func(message string) error {
+		selectedVarLog.Clear()
+		selectedVarLog.Highlight = false
+		fmt.Fprintln(selectedVarLog, "\033[31m"+message+"\033[0m")
+		return app.focusView(g, "varLogs")
+	}
+	// Клиент системы контейнеризации запускается на локальном хосте
+	if app.host != "" {
+		return showError("Container files are available only on the local host")
+	}
+	target := strings.TrimSpace(line)
+	// Файлы пода доступны только на уровне контейнеров: исполнитель создается по пути namespace/pod/container
+	if app.selectContainerizationSystem == "kubectl" {
+		if app.kubePod == "" {
+			return nil
+		}
+		name := target
+		target = ""
+		for _, object := range app.dockerContainers {
+			if removeANSI(object.name) == name {
+				target = object.id
+				break
+			}
+		}
+		if target == "" {
+			return nil
+		}
+	}
+	host := app.selectContainerizationSystem + "://" + target
+	// Операционная система контейнера нужна для формата команды stat
+	if err := app.connectHost(host, false); err != nil {
+		return showError("Error: " + err.Error())
+	}
+	app.containerFilesHost = host
+	app.selectPath = "container"
+	app.startFiles = 0
+	app.selectedFile = 0
+	selectedVarLog.Title = " < Container files: " + target + " (0) > "
+	app.loadFiles(app.selectPath)
+	return app.focusView(g, "varLogs")
+}
+
+// ---------------------------------------- Kubernetes ----------------------------------------
+
+// Под из вывода kubectl get pods -o json (только используемые поля)
+type kubePod struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Containers []struct {
+			Name string `json:"name"`
+		} `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase             string                `json:"phase"`
+		ContainerStatuses []kubeContainerStatus `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// Состояние контейнера пода: причина ожидания или завершения (CrashLoopBackOff, OOMKilled) и количество перезапусков
+type kubeContainerStatus struct {
+	Name         string `json:"name"`
+	RestartCount int    `json:"restartCount"`
+	State        struct {
+		Waiting *struct {
+			Reason string `json:"reason"`
+		} `json:"waiting"`
+		Terminated *struct {
+			Reason string `json:"reason"`
+		} `json:"terminated"`
+	} `json:"state"`
+}
+
+// Функция для получения состояния контейнера (running, если нет причины ожидания или завершения)
+func (status kubeContainerStatus) state() string {
+	switch {
+	case status.State.Waiting != nil && status.State.Waiting.Reason != "":
+		return status.State.Waiting.Reason
+	case status.State.Terminated != nil && status.State.Terminated.Reason != "":
+		return status.State.Terminated.Reason
+	}
+	return "Running"
+}
+
+// Функция для получения состояния пода (первая причина ожидания или завершения контейнера, иначе фаза) и суммы перезапусков контейнеров
+func (pod kubePod) state() (string, int) {
+	state := pod.Status.Phase
+	restarts := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+		if containerState := status.state(); containerState != "Running" && state == pod.Status.Phase {
+			state = containerState
+		}
+	}
+	return state, restarts
+}
+
+// Функция для получения названия пода или контейнера в списке с покраской по состоянию и количеством перезапусков
+func kubeObjectName(name string, state string, restarts int) string {
+	color := "\033[31m"
+	switch state {
+	case "Running", "Succeeded", "Completed":
+		color = "\033[32m"
+	case "Pending", "ContainerCreating", "PodInitializing":
+		color = "\033[33m"
+	}
+	return color + name + "\033[0m" + " (" + state + ", restarts: " + strconv.Itoa(restarts) + ")"
+}
+
+// Функция для загрузки текущего уровня списка Kubernetes: пространства имен, поды пространства имен или контейнеры пода
+// Для контейнеров в качестве идентификатора используется путь namespace/pod/container для загрузки журнала
+func (app *App) loadKubeObjects() ([]DockerContainers, error) {
+	var objects []DockerContainers
+	if app.kubeNamespace == "" {
+		output, err := app.command("kubectl", "get", "namespaces", "-o", "jsonpath={.items[*].metadata.name}").Output()
+		if err != nil {
+			return nil, err
+		}
+		for _, namespace := range strings.Fields(string(output)) {
+			objects = append(objects, DockerContainers{name: namespace, id: namespace})
+		}
+		return objects, nil
+	}
+	output, err := app.command("kubectl", "get", "pods", "-n", app.kubeNamespace, "-o", "json").Output()
+	if err != nil {
+		return nil, err
+	}
+	var podList struct {
+		Items []kubePod `json:"items"`
+	}
+	if err := json.Unmarshal(output, &podList); err != nil {
+		return nil, err
+	}
+	app.kubePods = podList.Items
+	if app.kubePod == "" {
+		for _, pod := range app.kubePods {
+			state, restarts := pod.state()
+			objects = append(objects, DockerContainers{name: kubeObjectName(pod.Metadata.Name, state, restarts), id: pod.Metadata.Name})
+		}
+		return objects, nil
+	}
+	for _, pod := range app.kubePods {
+		if pod.Metadata.Name != app.kubePod {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			status := kubeContainerStatus{Name: container.Name}
+			for _, containerStatus := range pod.Status.ContainerStatuses {
+				if containerStatus.Name == container.Name {
+					status = containerStatus
+				}
+			}
+			objects = append(objects, DockerContainers{
+				name: kubeObjectName(container.Name, status.state(), status.RestartCount),
+				id:   app.kubeNamespace + "/" + pod.Metadata.Name + "/" + container.Name,
+			})
+		}
+	}
+	return objects, nil
+}
+
+// Функция для получения заголовка окна контейнеров для текущего уровня списка Kubernetes
+func (app *App) kubeTitle() string {
+	switch {
+	case app.kubeNamespace == "":
+		return " < Kubernetes namespaces (0) > "
+	case app.kubePod == "":
+		return " < Pods: " + app.kubeNamespace + " (0) > "
+	}
+	return " < Containers: " + app.kubePod + " (0) > "
+}
+
+// Функция для перехода к уровню списка Kubernetes (пустое пространство имен - список пространств имен)
+func (app *App) openKubeLevel(namespace string, pod string) {
+	app.kubeNamespace = namespace
+	app.kubePod = pod
+	app.dockerContainers = app.dockerContainers[:0]
+	app.startDockerContainers = 0
+	app.selectedDockerContainer = 0
+	if !app.noGui() {
+		if v, err := app.gui.View("docker"); err == nil {
+			v.Title = app.kubeTitle()
+		}
+	}
+	app.loadDockerContainer("kubectl")
+}
+
+// Функция для выбора элемента списка Kubernetes: пространство имен открывает список подов, под - список контейнеров
+// Возвращает путь namespace/pod/container для загрузки журнала (журнал пода с одним контейнером открывается сразу)
+func (app *App) selectKubeObject(name string) string {
+	var id string
+	for _, object := range app.dockerContainers {
+		if removeANSI(object.name) == name {
+			id = object.id
+			break
+		}
+	}
+	switch {
+	case id == "":
+		return ""
+	case app.kubeNamespace == "":
+		app.openKubeLevel(id, "")
+		return ""
+	case app.kubePod == "":
+		for _, pod := range app.kubePods {
+			if pod.Metadata.Name == id && len(pod.Spec.Containers) == 1 {
+				return app.kubeNamespace + "/" + id + "/" + pod.Spec.Containers[0].Name
+			}
+		}
+		app.openKubeLevel(app.kubeNamespace, id)
+		return ""
+	}
+	return id
+}
+
+// Функция для возврата к предыдущему уровню списка Kubernetes (Backspace)
+func (app *App) kubeLevelUp(g *gocui.Gui, v *gocui.View) error {
+	switch {
+	case app.selectContainerizationSystem != "kubectl" || app.kubeNamespace == "":
+		return nil
+	case app.kubePod != "":
+		app.openKubeLevel(app.kubeNamespace, "")
+	default:
+		app.openKubeLevel("", "")
+	}
+	return nil
+}
+
+// Поток журнала контейнера пода (kubectl logs -f): строки читаются в фоне и выводятся при обновлении окна
+// Поток завершается вместе с контейнером (например, при перезапуске пода) и запускается заново при следующем обновлении
+type logStream struct {
+	args  []string // параметры kubectl, с которыми запущен поток
+	limit int      // количество последних строк для вывода
+	cmd   *exec.Cmd
+
+	mu    sync.Mutex
+	lines []string
+	done  bool
+	err   error
+}
+
+// Функция для запуска потока журнала (stdout и stderr читаются вместе, как в CombinedOutput)
+func startLogStream(cmd *exec.Cmd, args []string, limit int) *logStream {
+	stream := &logStream{args: args, limit: limit, cmd: cmd}
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		stream.done, stream.err = true, err
+		return stream
+	}
+	// Ошибка завершения команды передается читателю вместо конца потока
+	go func() {
+		writer.CloseWithError(cmd.Wait())
+	}()
+	go func() {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			stream.mu.Lock()
+			stream.lines = append(stream.lines, scanner.Text())
+			// Старые строки удаляются с запасом, что бы не копировать срез на каждой строке
+			if len(stream.lines) > 2*stream.limit {
+				stream.lines = slices.Clone(stream.lines[len(stream.lines)-stream.limit:])
+			}
+			stream.mu.Unlock()
+		}
+		stream.mu.Lock()
+		stream.done, stream.err = true, scanner.Err()
+		stream.mu.Unlock()
+	}()
+	return stream
+}
+
+// Функция для получения последних строк потока, признака завершения и ошибки команды
+func (stream *logStream) snapshot() ([]string, bool, error) {
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	lines := stream.lines
+	if len(lines) > stream.limit {
+		lines = lines[len(lines)-stream.limit:]
+	}
+	return slices.Clone(lines), stream.done, stream.err
+}
+
+// Функция для ожидания первичного вывода потока: до завершения команды или паузы в выводе (не более 2 секунд)
+func (stream *logStream) waitOutput() {
+	count := -1
+	for range 40 {
+		lines, done, _ := stream.snapshot()
+		if done || len(lines) > 0 && len(lines) == count {
+			return
+		}
+		count = len(lines)
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// Функция для остановки потока журнала окна (при закрытии вкладки или второго окна)
+func (pane *logPane) stopStream() {
+	if pane.stream == nil {
+		return
+	}
+	if pane.stream.cmd.Process != nil {
+		_ = pane.stream.cmd.Process.Kill()
+	}
+	pane.stream = nil
+}
+
+// Функция для получения журнала контейнера пода из потока kubectl logs -f
+// Поток запускается заново при смене контейнера или экземпляра (--previous) и после завершения предыдущего потока
+func (app *App) streamPodLogs(containerId string) ([]byte, error) {
+	args := []string{"logs", "-f", "--timestamps", "--tail", app.logViewCount}
+	if path := strings.SplitN(containerId, "/", 3); len(path) == 3 {
+		args = append(args, "-n", path[0], path[1], "-c", path[2])
+	} else {
+		args = append(args, containerId)
+	}
+	if app.previousInstance {
+		args = append(args, "--previous")
+	}
+	restart := app.stream == nil || !slices.Equal(app.stream.args, args)
+	if !restart {
+		_, done, _ := app.stream.snapshot()
+		restart = done
+	}
+	if restart {
+		app.stopStream()
+		limit, _ := strconv.Atoi(app.logViewCount)
+		app.stream = startLogStream(app.command("kubectl", args...), args, limit)
+		app.stream.waitOutput()
+	}
+	lines, done, err := app.stream.snapshot()
+	if !done {
+		err = nil
+	}
+	if len(lines) == 0 {
+		return nil, err
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), err
+}
+
+// Функция для переключения между журналом текущего и предыдущего (завершившегося) экземпляра контейнера пода
+func (app *App) togglePreviousInstance() {
+	if app.lastWindow != "docker" || app.lastContainerizationSystem != "kubectl" {
+		return
+	}
+	app.previousInstance = !app.previousInstance
+	app.loadDockerLogs(app.lastSelected, false)
+}
+
+// ---------------------------------------- Filter ----------------------------------------
+
+// Редактор обработки ввода текста для фильтрации
+func (app *App) createFilterEditor(window string) gocui.Editor {
+	return gocui.EditorFunc(func(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+		switch {
+		// добавляем символ в поле ввода
+		case ch != 0 && mod == 0:
+			v.EditWrite(ch)
+		// добавляем пробел
+		case key == gocui.KeySpace:
+			v.EditWrite(' ')
+		// удаляем символ слева от курсора
+		case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
+			v.EditDelete(true)
+		// Удаляем символ справа от курсора
+		case key == gocui.KeyDelete:
+			v.EditDelete(false)
+		// Перемещение курсора влево
+		case key == gocui.KeyArrowLeft:
+			v.MoveCursor(-1, 0) // удалить 3-й булевой параметр для форка
+		// Перемещение курсора вправо
+		case key == gocui.KeyArrowRight:
+			v.MoveCursor(1, 0)
+		}
+		if window == "logs" {
+			// Обновляем текст в буфере
+			app.filterText = strings.TrimSpace(v.Buffer())
+			// Применяем функцию фильтрации к выводу записей журнала
+			app.applyFilter(true)
+		} else if window == "lists" {
+			app.filterListText = strings.TrimSpace(v.Buffer())
+			app.applyFilterList()
+		}
+	})
+}
+
+// Функция для фильтрации всех списоков журналов
+func (app *App) applyFilterList() {
+	filter := strings.ToLower(app.filterListText)
+	// Временные массивы для отфильтрованных журналов
+	var filteredJournals []Journal
+	var filteredLogFiles []Logfile
+	var filteredDockerContainers []DockerContainers
+	for _, j := range app.journalsNotFilter {
+		if strings.Contains(strings.ToLower(j.name), filter) {
+			filteredJournals = append(filteredJournals, j)
+		}
+	}
+	for _, j := range app.logfilesNotFilter {
+		if strings.Contains(strings.ToLower(j.name), filter) {
+			filteredLogFiles = append(filteredLogFiles, j)
+		}
+	}
+	for _, j := range app.dockerContainersNotFilter {
+		if strings.Contains(strings.ToLower(j.name), filter) {
+			filteredDockerContainers = append(filteredDockerContainers, j)
+		}
+	}
+	// Сбрасываем индексы выбранного журнала для правильного позиционирования
+	app.selectedJournal = 0
+	app.selectedFile = 0
+	app.selectedDockerContainer = 0
+	app.startServices = 0
 	app.startFiles = 0
+	app.startDockerContainers = 0
 	// Сохраняем отфильтрованные и отсортированные данные
 	app.journals = filteredJournals
 	app.logfiles = filteredLogFiles
+	app.dockerContainers = filteredDockerContainers
 	// Обновляем статус количества служб
-	if !app.testMode {
+	if !app.noGui() {
 		// Обновляем списки в интерфейсе
 		app.updateServicesList()
 		app.updateLogsList()
+		app.updateDockerContainerList()
 		v, _ := app.gui.View("services")
 		// Обновляем счетчик в заголовке
 		re := regexp.MustCompile(`\s\(.+\) >`)
@@ -2309,9 +3332,13 @@ func (app *App) applyFilterList() {
 		}
 		v.Title = re.ReplaceAllString(v.Title, updateTitle)
 		// Обновляем статус количества контейнеров
+		v, _ = app.gui.View("docker")
 		// Обновляем счетчик в заголовке
 		re = regexp.MustCompile(`\s\(.+\) >`)
 		updateTitle = " (0) >"
+		if len(app.dockerContainers) != 0 {
+			updateTitle = " (" + strconv.Itoa(app.selectedDockerContainer+1) + "/" + strconv.Itoa(len(app.dockerContainers)) + ") >"
+		}
 		v.Title = re.ReplaceAllString(v.Title, updateTitle)
 	}
 }
@@ -2323,7 +3350,7 @@ func (app *App) applyFilter(color bool) {
 	var size int
 	var viewHeight int
 	var err error
-	if !app.testMode {
+	if !app.noGui() {
 		v, err := app.gui.View("filter")
 		if err != nil {
 			return
@@ -2332,7 +3359,7 @@ func (app *App) applyFilter(color bool) {
 			v.FrameColor = gocui.ColorGreen
 		}
 		// Debug: если текст фильтра не менялся и позиция курсора не в самом конце журнала, то пропускаем фильтрацию и покраску при пролистывании
-		vLogs, _ := app.gui.View("logs")
+		vLogs, _ := app.gui.View(app.logsViewName())
 		_, viewHeight := vLogs.Size()
 		size = app.logScrollPos + viewHeight + 1
 		if app.lastFilterText == filter && size < len(app.filteredLogLines) {
@@ -2345,116 +3372,107 @@ func (app *App) applyFilter(color bool) {
 	if !skip {
 		// Debug start time
 		startTime := time.Now()
+		// Преобразуем структурированные строки (JSON) в компактный или развернутый вид перед фильтрацией
+		logLines := app.renderStructuredLines(app.currentLogLines)
+		// Колонки журнала доступа выравниваются только в выводе, фильтр проверяется по строкам без выравнивания
+		displayLines := app.alignAccessLogLines(logLines)
+		// Строки, которые подошли под фильтр (nil, если фильтр пустой), и строки с покраской найденных совпадений
+		var matchLines []bool
+		var highlightLines []string
 		// Debug: если текст фильтра пустой или равен любому символу для regex, возвращяем вывод без фильтрации
-		if filter == "" || (filter == "." && app.selectFilterMode == "regex") {
-			app.filteredLogLines = app.currentLogLines
+		if app.patternFilter == nil && (filter == "" || (filter == "." && app.selectFilterMode == "regex")) {
+			highlightLines = displayLines
 		} else {
-			app.filteredLogLines = make([]string, 0)
-			// Опускаем регистр ввода текста для фильтра
-			filter = strings.ToLower(filter)
+			matchLines = make([]bool, len(logLines))
+			highlightLines = make([]string, len(logLines))
+			// Извлекаем из текста фильтра условия по полям структурированных журналов (level=error user.id=42)
+			// и текст для поиска в структурированных строках без этих условий
+			var fieldConditions []fieldCondition
+			textFilter := filter
+			if app.selectFilterMode != "regex" {
+				fieldConditions, textFilter = parseFieldFilter(filter)
+			}
 			// Проверка регулярного выражения
 			var regex *regexp.Regexp
 			if app.selectFilterMode == "regex" {
-				// Добавляем флаг для нечувствительности к регистру по умолчанию
-				filter = "(?i)" + filter
-				// Компилируем регулярное выражение
-				regex, err = regexp.Compile(filter)
+				// Добавляем флаг для нечувствительности к регистру по умолчанию и компилируем регулярное выражение
+				regex, err = regexp.Compile("(?i)" + filter)
 				// В случае синтаксической ошибки регулярного выражения, красим окно красным цветом и завершаем цикл
-				if err != nil && !app.testMode {
+				if err != nil && !app.noGui() {
 					v, _ := app.gui.View("filter")
 					v.FrameColor = gocui.ColorRed
 					return
 				}
-				if err != nil && !app.testMode {
+				if err != nil && !app.noGui() {
 					log.Print("Error: regex syntax")
 					return
 				}
 			}
 			// Проходимся по каждой строке
-			for _, line := range app.currentLogLines {
-				switch {
-				// Fuzzy (неточный поиск без учета регистра)
-				case app.selectFilterMode == "fuzzy":
-					// Разбиваем текст фильтра на массив из строк
-					filterWords := strings.Fields(filter)
-					// Опускаем регистр текущей строки цикла
-					lineLower := strings.ToLower(line)
-					var match bool = true
-					// Проверяем, если строка не содержит хотя бы одно слово из фильтра, то пропускаем строку
-					for _, word := range filterWords {
-						if !strings.Contains(lineLower, word) {
-							match = false
-							break
+			for i, line := range logLines {
+				// Оставляем только строки выбранного шаблона
+				if app.patternFilter != nil && !app.patternFilter[patternMask(app.currentLogLines[i])] {
+					continue
+				}
+				// Условия по полям проверяются только для структурированных строк, в остальных строках ищется весь текст фильтра
+				lineFilter := filter
+				if len(fieldConditions) != 0 {
+					if fields := app.parseLineFields(app.currentLogLines[i]); fields != nil {
+						if !app.matchFieldConditions(fields, line, fieldConditions) {
+							continue
 						}
+						lineFilter = textFilter
 					}
-					// Если строка подходит под фильтр, возвращаем ее с покраской
-					if match {
-						// Временные символы для обозначения начала и конца покраски найденных символов
-						startColor := "►"
-						endColor := "◄"
-						originalLine := line
-						// Проходимся по всем словосочетаниям фильтра (массив через пробел) для позиционирования покраски
-						for _, word := range filterWords {
-							wordLower := strings.ToLower(word)
-							start := 0
-							// Ищем все вхождения слова в строке с учетом регистра
-							for {
-								// Находим индекс вхождения с учетом регистра
-								idx := strings.Index(strings.ToLower(originalLine[start:]), wordLower)
-								if idx == -1 {
-									break // Если больше нет вхождений, выходим
-								}
-								start += idx // корректируем индекс с учетом текущей позиции
-								// Вставляем временные символы для покраски
-								originalLine = originalLine[:start] + startColor + originalLine[start:start+len(word)] + endColor + originalLine[start+len(word):]
-								// Сдвигаем индекс для поиска в оставшейся части строки
-								start += len(startColor) + len(word) + len(endColor)
-							}
+				}
+				if filteredLine, match := app.filterLine(line, lineFilter, regex); match {
+					matchLines[i] = true
+					highlightLines[i] = filteredLine
+					// Совпадение в выровненной строке выделяется, если оно не разделено выравниванием (иначе строка выводится без выделения)
+					if displayLines[i] != line {
+						highlightLines[i] = displayLines[i]
+						if filteredLine, match := app.filterLine(displayLines[i], lineFilter, regex); match {
+							highlightLines[i] = filteredLine
 						}
-						// Заменяем временные символы на ANSI escape-последовательности
-						originalLine = strings.ReplaceAll(originalLine, startColor, "\x1b[0;44m")
-						originalLine = strings.ReplaceAll(originalLine, endColor, "\033[0m")
-						app.filteredLogLines = append(app.filteredLogLines, originalLine)
-					}
-				// Regex (с использованием регулярных выражений и без учета регистра по умолчанию)
-				case app.selectFilterMode == "regex":
-					// Проверяем, что строка подходит под регулярное выражение
-					if regex.MatchString(line) {
-						originalLine := line
-						// Находим все найденные совпадени
-						matches := regex.FindAllString(originalLine, -1)
-						// Красим только первое найденное совпадение
-						originalLine = strings.ReplaceAll(originalLine, matches[0], "\x1b[0;44m"+matches[0]+"\033[0m")
-						app.filteredLogLines = append(app.filteredLogLines, originalLine)
-					}
-				// Default (точный поиск с учетом регистра)
-				default:
-					filter = app.filterText
-					if filter == "" || strings.Contains(line, filter) {
-						lineColor := strings.ReplaceAll(line, filter, "\x1b[0;44m"+filter+"\033[0m")
-						app.filteredLogLines = append(app.filteredLogLines, lineColor)
 					}
 				}
 			}
 		}
-		// Если последняя строка не содержит пустую строку, то добавляем ее
-		if len(app.filteredLogLines) > 0 && app.filteredLogLines[len(app.filteredLogLines)-1] != "" {
-			app.filteredLogLines = append(app.filteredLogLines, "")
+		// Объединяем многострочные записи (стек вызовов) в группы, которые выводятся целиком при совпадении в любой строке группы
+		// Для строк вывода также формируются пометки, которые добавляются после покраски (количество скрытых или повторяющихся строк)
+		// Для каждой строки вывода сохраняется позиция исходной строки (для перехода к строке в файле и в полном журнале)
+		app.filteredLines = app.groupFilteredLines(displayLines, highlightLines, matchLines)
+		// Объединяем повторяющиеся строки подряд в одну строку с количеством повторений
+		if app.dedupMode {
+			app.filteredLines = dedupLines(app.filteredLines)
+		}
+		// Разбиваем развернутые объекты JSON на отдельные строки вывода
+		if app.jsonExpandMode {
+			app.filteredLines = splitMultilineGroups(app.filteredLines)
+		}
+		// Если последняя строка не содержит пустую строку, то добавляем ее (пустая строка в конце не относится к исходным строкам)
+		if len(app.filteredLines) > 0 && app.filteredLines[len(app.filteredLines)-1].text != "" {
+			app.filteredLines = append(app.filteredLines, logLine{index: -1})
+		} else if len(app.filteredLines) > 0 {
+			app.filteredLines[len(app.filteredLines)-1] = logLine{index: -1}
+		}
+		// Отмечаем строки с закладками текущего источника
+		app.markBookmarkedLines()
+		app.filteredLogLines = make([]string, len(app.filteredLines))
+		for i, line := range app.filteredLines {
+			app.filteredLogLines[i] = line.text
+		}
+		// Распределяем строки по интервалам времени для панели Timeline (до покраски)
+		if app.timelineMode {
+			app.updateTimeline()
 		}
 		// Отключаем покраску в режиме colorMode
 		if app.colorMode {
 			// Режим покраски через tailspin
 			if app.tailSpinMode {
-				cmd := exec.Command("tailspin")
-				logLines := strings.Join(app.filteredLogLines, "\n")
-				// Создаем пайп для передачи данных
-				cmd.Stdin = bytes.NewBufferString(logLines)
-				var out bytes.Buffer
-				cmd.Stdout = &out
-				if err := cmd.Run(); err != nil {
+				colorLogLines, err := tailSpinColor(app.filteredLogLines)
+				if err != nil {
 					fmt.Println(err)
 				}
-				colorLogLines := strings.Split(out.String(), "\n")
 				app.filteredLogLines = colorLogLines
 			} else {
 				// Максимальное количество потоков
@@ -2491,6 +3509,16 @@ func (app *App) applyFilter(color bool) {
 				app.filteredLogLines = colorLogLines
 			}
 		}
+		// Добавляем пометки к строкам после покраски
+		for i, line := range app.filteredLines {
+			if line.suffix != "" && i < len(app.filteredLogLines) {
+				app.filteredLogLines[i] += line.suffix
+			}
+		}
+		// Добавляем номера строк в начало строк вывода
+		if app.lineNumbers {
+			app.addLineNumbers()
+		}
 		// Debug end time
 		endTime := time.Since(startTime)
 		app.debugLoadTime = endTime.Truncate(time.Millisecond).String()
@@ -2505,7 +3533,7 @@ func (app *App) applyFilter(color bool) {
 		}
 	}
 	// Обновляем окно для отображения отфильтрованных записей
-	if !app.testMode {
+	if !app.noGui() {
 		if app.autoScroll {
 			app.logScrollPos = 0
 			app.updateLogsView(true)
@@ -2515,1611 +3543,7553 @@ func (app *App) applyFilter(color bool) {
 	}
 }
 
-// ---------------------------------------- Coloring ----------------------------------------
+// Функция для проверки строки на соответствие текущему режиму фильтрации и покраски найденных совпадений
+func (app *App) filterLine(line string, filter string, regex *regexp.Regexp) (string, bool) {
+	// Пустой текст (например, если в фильтре указаны только условия по полям) пропускает все строки
+	if filter == "" {
+		return line, true
+	}
+	switch {
+	// Fuzzy (неточный поиск без учета регистра)
+	case app.selectFilterMode == "fuzzy":
+		// Разбиваем текст фильтра на массив из строк в нижнем регистре
+		filterWords := strings.Fields(strings.ToLower(filter))
+		// Опускаем регистр текущей строки цикла
+		lineLower := strings.ToLower(line)
+		// Проверяем, если строка не содержит хотя бы одно слово из фильтра, то пропускаем строку
+		for _, word := range filterWords {
+			if !strings.Contains(lineLower, word) {
+				return "", false
+			}
+		}
+		// Временные символы для обозначения начала и конца покраски найденных символов
+		startColor := "►"
+		endColor := "◄"
+		originalLine := line
+		// Проходимся по всем словосочетаниям фильтра (массив через пробел) для позиционирования покраски
+		for _, word := range filterWords {
+			start := 0
+			// Ищем все вхождения слова в строке с учетом регистра
+			for {
+				// Находим индекс вхождения с учетом регистра
+				idx := strings.Index(strings.ToLower(originalLine[start:]), word)
+				if idx == -1 {
+					break // Если больше нет вхождений, выходим
+				}
+				start += idx // корректируем индекс с учетом текущей позиции
+				// Вставляем временные символы для покраски
+				originalLine = originalLine[:start] + startColor + originalLine[start:start+len(word)] + endColor + originalLine[start+len(word):]
+				// Сдвигаем индекс для поиска в оставшейся части строки
+				start += len(startColor) + len(word) + len(endColor)
+			}
+		}
+		// Заменяем временные символы на ANSI escape-последовательности
+		originalLine = strings.ReplaceAll(originalLine, startColor, "\x1b[0;44m")
+		originalLine = strings.ReplaceAll(originalLine, endColor, "\033[0m")
+		return originalLine, true
+	// Regex (с использованием регулярных выражений и без учета регистра по умолчанию)
+	case app.selectFilterMode == "regex":
+		// Проверяем, что строка подходит под регулярное выражение
+		if regex.MatchString(line) {
+			// Находим все найденные совпадени
+			matches := regex.FindAllString(line, -1)
+			// Красим только первое найденное совпадение
+			return strings.ReplaceAll(line, matches[0], "\x1b[0;44m"+matches[0]+"\033[0m"), true
+		}
+	// Default (точный поиск с учетом регистра)
+	default:
+		if strings.Contains(line, filter) {
+			return strings.ReplaceAll(line, filter, "\x1b[0;44m"+filter+"\033[0m"), true
+		}
+	}
+	return "", false
+}
 
-// Функция для покраски строки
-func (app *App) lineColor(inputLine string) string {
-	// Разбиваем строку на слова
-	words := strings.Fields(inputLine)
-	var colorLine string
-	var filterColor bool = false
-	for _, word := range words {
-		// Исключаем строки с покраской при поиске (Background)
-		if strings.Contains(word, "\x1b[0;44m") {
-			filterColor = true
+// ---------------------------------------- Structured logs ----------------------------------------
+
+// Ключи для извлечения времени, уровня и сообщения из журналов в формате JSON (в порядке приоритета)
+var (
+	jsonTimeKeys    = []string{"time", "timestamp", "ts", "@timestamp", "datetime", "date", "t"}
+	jsonLevelKeys   = []string{"level", "lvl", "severity", "loglevel", "log.level", "@level", "l"}
+	jsonMessageKeys = []string{"msg", "message", "@message", "log", "m"}
+)
+
+// Условие фильтрации по полю структурированного журнала (level=error)
+type fieldCondition struct {
+	key      string         // имя поля (для вложенных объектов через точку: user.id)
+	operator string         // оператор сравнения
+	value    string         // ожидаемое значение
+	text     string         // исходное слово фильтра (ищется в тексте строки, если поля нет)
+	regex    *regexp.Regexp // регулярное выражение для операторов ~ и !~
+}
+
+// Условие фильтра: имя поля, оператор и значение без пробелов
+var fieldConditionRegex = regexp.MustCompile(`^([a-zA-Z_@][a-zA-Z0-9_.@\-]*)(!=|!~|>=|<=|=|~|>|<)(.+)$`)
+
+// Функция для извлечения условий по полям из текста фильтра, возвращает условия и оставшийся текст для поиска
+func parseFieldFilter(filter string) ([]fieldCondition, string) {
+	var conditions []fieldCondition
+	var words []string
+	for _, word := range strings.Fields(filter) {
+		match := fieldConditionRegex.FindStringSubmatch(word)
+		if match == nil {
+			words = append(words, word)
+			continue
 		}
-		// Красим слово в функции
-		if !filterColor {
-			word = app.wordColor(word)
+		condition := fieldCondition{
+			key:      match[1],
+			operator: match[2],
+			value:    strings.Trim(match[3], `"'`),
+			text:     word,
 		}
-		// Возобновляем покраску
-		if strings.Contains(word, "\033[0m") {
-			filterColor = false
+		// Для операторов ~ и !~ значение используется как регулярное выражение без учета регистра (path~^/api)
+		if strings.HasSuffix(condition.operator, "~") {
+			regex, err := regexp.Compile("(?i)" + condition.value)
+			if err != nil {
+				words = append(words, word)
+				continue
+			}
+			condition.regex = regex
 		}
-		colorLine += word + " "
+		conditions = append(conditions, condition)
 	}
-	return strings.TrimSpace(colorLine)
+	// Если условий нет, возвращаем текст фильтра без изменений (с сохранением пробелов)
+	if len(conditions) == 0 {
+		return nil, filter
+	}
+	return conditions, strings.Join(words, " ")
 }
 
-// Игнорируем регистр и проверяем, что слово окружено границами (не буквы и цифры)
-func (app *App) replaceWordLower(word, keyword, color string) string {
-	re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(keyword) + `\b`)
-	return re.ReplaceAllStringFunc(word, func(match string) string {
-		return color + match + "\033[0m"
-	})
+// Функция для извлечения полей из структурированной строки журнала (JSON, журнал доступа веб-сервера или logfmt)
+func (app *App) parseLineFields(line string) map[string]string {
+	if parsed := app.parseJsonLine(line); parsed != nil && parsed.fields != nil {
+		return parsed.fields
+	}
+	if fields, ok := app.parseAccessLogFields(line); ok {
+		return fields
+	}
+	if fields, ok := parseLogfmtFields(line); ok {
+		return fields
+	}
+	return nil
 }
 
-// Поиск пользователей
-func (app *App) containsUser(searchWord string) bool {
-	for _, user := range app.userNameArray {
-		if user == searchWord {
-			return true
+// Функция для проверки всех условий фильтра по полям структурированной строки
+// Если поле отсутствует, условие ищется как текст в отображаемой строке с учетом регистра режима фильтра
+func (app *App) matchFieldConditions(fields map[string]string, line string, conditions []fieldCondition) bool {
+	for _, condition := range conditions {
+		value, ok := lookupField(fields, condition.key)
+		if condition.regex != nil {
+			if !ok || condition.regex.MatchString(value) == (condition.operator == "!~") {
+				return false
+			}
+			continue
+		}
+		if !ok {
+			text, word := removeANSI(line), condition.text
+			if app.selectFilterMode == "fuzzy" {
+				text, word = strings.ToLower(text), strings.ToLower(word)
+			}
+			if !strings.Contains(text, word) {
+				return false
+			}
+			continue
+		}
+		if !compareFieldValue(value, condition.operator, condition.value) {
+			return false
 		}
 	}
-	return false
+	return true
 }
 
-// Поиск корневых директорий
-func (app *App) containsPath(searchWord string) bool {
-	for _, dir := range app.rootDirArray {
-		if strings.Contains(searchWord, dir) {
-			return true
+// Функция для поиска поля без учета регистра
+// Для времени, уровня, сообщения и полей журнала доступа также проверяются синонимы (level=error найдет поле severity)
+func lookupField(fields map[string]string, key string) (string, bool) {
+	if fields == nil {
+		return "", false
+	}
+	if value, ok := fields[key]; ok {
+		return value, true
+	}
+	for fieldKey, value := range fields {
+		if strings.EqualFold(fieldKey, key) {
+			return value, true
+		}
+	}
+	for _, keys := range append([][]string{jsonTimeKeys, jsonLevelKeys, jsonMessageKeys}, accessLogFieldAliases...) {
+		if slices.Contains(keys, strings.ToLower(key)) {
+			if aliasKey, value := takeField(fields, keys); aliasKey != "" {
+				return value, true
+			}
 		}
 	}
+	return "", false
+}
+
+// Функция для сравнения значения поля с условием фильтра
+// Для операторов больше/меньше значения сравниваются как числа, затем как длительность (500ms) и как строки
+func compareFieldValue(value string, operator string, expected string) bool {
+	switch operator {
+	case "=":
+		return strings.EqualFold(value, expected)
+	case "!=":
+		return !strings.EqualFold(value, expected)
+	}
+	var compare int
+	valueNumber, valueErr := strconv.ParseFloat(value, 64)
+	expectedNumber, expectedErr := strconv.ParseFloat(expected, 64)
+	valueDuration, valueDurationErr := time.ParseDuration(value)
+	expectedDuration, expectedDurationErr := time.ParseDuration(expected)
+	switch {
+	case valueErr == nil && expectedErr == nil:
+		compare = cmp.Compare(valueNumber, expectedNumber)
+	case valueDurationErr == nil && expectedDurationErr == nil:
+		compare = cmp.Compare(valueDuration, expectedDuration)
+	// Число без единиц измерения сравниваем с длительностью в миллисекундах (duration>500 для 750ms)
+	case valueDurationErr == nil && expectedErr == nil:
+		compare = cmp.Compare(float64(valueDuration)/float64(time.Millisecond), expectedNumber)
+	default:
+		compare = strings.Compare(value, expected)
+	}
+	switch operator {
+	case ">":
+		return compare > 0
+	case ">=":
+		return compare >= 0
+	case "<":
+		return compare < 0
+	case "<=":
+		return compare <= 0
+	}
 	return false
 }
 
-// Покраска url путей
-func (app *App) urlPathColor(cleanedWord string) string {
-	// Используем Builder для объединения строк
-	var sb strings.Builder
-	// Начинаем с желтого цвета
-	sb.WriteString("\033[33m")
-	for _, char := range cleanedWord {
+// Функция для проверки имени ключа в формате logfmt
+func isLogfmtKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, char := range key {
 		switch {
-		// Пурпурный цвет для символов и возвращяем желтый
-		case char == '/' || char == '?' || char == '&' || char == '=' || char == ':' || char == '.':
-			sb.WriteString("\033[35m")
-			sb.WriteRune(char)
-			sb.WriteString("\033[33m")
-		// Синий цвет для цифр
-		// case unicode.IsDigit(char):
-		case char >= '0' && char <= '9':
-			sb.WriteString("\033[34m")
-			sb.WriteRune(char)
-			sb.WriteString("\033[33m")
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char == '_', char == '@':
+		case i > 0 && (char >= '0' && char <= '9' || char == '.' || char == '-'):
 		default:
-			sb.WriteRune(char)
+			return false
 		}
 	}
-	// Сброс цвета
-	sb.WriteString("\033[0m")
-	return sb.String()
+	return true
 }
 
-// Функция для покраски словосочетаний
-func (app *App) wordColor(inputWord string) string {
-	// Опускаем регистр слова
-	inputWordLower := strings.ToLower(inputWord)
-	// Значение по умолчанию
-	var coloredWord string = inputWord
-	switch {
-	// URL
-	case strings.Contains(inputWord, "http://"):
-		cleanedWord := app.trimHttpRegex.ReplaceAllString(inputWord, "")
-		coloredChars := app.urlPathColor(cleanedWord)
-		// Красный для http
-		coloredWord = strings.ReplaceAll(inputWord, "http://"+cleanedWord, "\033[31mhttp\033[35m://"+coloredChars)
-	case strings.Contains(inputWord, "https://"):
-		cleanedWord := app.trimHttpsRegex.ReplaceAllString(inputWord, "")
-		coloredChars := app.urlPathColor(cleanedWord)
-		// Зеленый для https
-		coloredWord = strings.ReplaceAll(inputWord, "https://"+cleanedWord, "\033[32mhttps\033[35m://"+coloredChars)
-	// UNIX file paths
-	case app.containsPath(inputWord):
-		cleanedWord := app.trimPrefixPathRegex.ReplaceAllString(inputWord, "")
-		cleanedWord = app.trimPostfixPathRegex.ReplaceAllString(cleanedWord, "")
-		// Начинаем с желтого цвета
-		coloredChars := "\033[33m"
-		for _, char := range cleanedWord {
-			// Красим символы разделителя путей в пурпурный и возвращяем цвет
-			if char == '/' {
-				coloredChars += "\033[35m" + string(char) + "\033[33m"
-			} else {
-				coloredChars += string(char)
-			}
+// Функция для чтения строки в формате logfmt (time=... level=warn msg="..." err="...")
+// Строка считается logfmt, если содержит не менее двух пар key=value, одна из которых уровень или сообщение (или не менее трех пар)
+func parseLogfmtFields(line string) (map[string]string, bool) {
+	if !strings.Contains(line, "=") {
+		return nil, false
+	}
+	fields := make(map[string]string)
+	knownKey := false
+	position := 0
+	for position < len(line) {
+		// Пропускаем пробелы
+		if line[position] == ' ' || line[position] == '\t' {
+			position++
+			continue
 		}
-		coloredWord = strings.ReplaceAll(inputWord, cleanedWord, "\033[35m"+coloredChars+"\033[0m")
-	// Желтый (известные имена: hostname и username) [33m]
-	case strings.Contains(inputWord, app.hostName):
-		coloredWord = strings.ReplaceAll(inputWord, app.hostName, "\033[33m"+app.hostName+"\033[0m")
-	case strings.Contains(inputWord, app.userName):
-		coloredWord = strings.ReplaceAll(inputWord, app.userName, "\033[33m"+app.userName+"\033[0m")
-	// Список пользователей из passwd
-	case app.containsUser(inputWord):
-		coloredWord = app.replaceWordLower(inputWord, inputWord, "\033[33m")
-	case strings.Contains(inputWordLower, "warn"):
-		words := []string{"warnings", "warning", "warn"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[33m")
-				break
-			}
+		// Читаем слово до пробела или знака равенства
+		start := position
+		for position < len(line) && line[position] != ' ' && line[position] != '\t' && line[position] != '=' {
+			position++
 		}
-	// UNIX processes
-	case app.syslogUnitRegex.MatchString(inputWord):
-		unitSplit := strings.Split(inputWord, "[")
-		unitName := unitSplit[0]
-		unitId := strings.ReplaceAll(unitSplit[1], "]:", "")
-		coloredWord = strings.ReplaceAll(inputWord, inputWord, "\033[36m"+unitName+"\033[0m"+"\033[33m"+"["+"\033[0m"+"\033[34m"+unitId+"\033[0m"+"\033[33m"+"]"+"\033[0m"+":")
-	case strings.HasPrefix(inputWordLower, "kernel:"):
-		coloredWord = app.replaceWordLower(inputWord, "kernel", "\033[36m")
-	case strings.HasPrefix(inputWordLower, "rsyslogd:"):
-		coloredWord = app.replaceWordLower(inputWord, "rsyslogd", "\033[36m")
-	case strings.HasPrefix(inputWordLower, "sudo:"):
-		coloredWord = app.replaceWordLower(inputWord, "sudo", "\033[36m")
-	// Исключения
-	case strings.Contains(inputWordLower, "unblock"):
-		words := []string{"unblocking", "unblocked", "unblock"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
+		key := line[start:position]
+		// Слово без значения (префикс строки) пропускаем
+		if position >= len(line) || line[position] != '=' || !isLogfmtKey(key) {
+			for position < len(line) && line[position] != ' ' && line[position] != '\t' {
+				position++
 			}
+			continue
 		}
-	// Красный (ошибки) [31m]
-	case strings.Contains(inputWordLower, "err"):
-		words := []string{"stderr", "errors", "error", "erro", "err"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
+		position++
+		// Значение в кавычках (с экранированием) или до пробела
+		var value string
+		if position < len(line) && line[position] == '"' {
+			end := position + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
 			}
-		}
-	case strings.Contains(inputWordLower, "dis"):
-		words := []string{"disconnected", "disconnection", "disconnects", "disconnect", "disabled", "disabling", "disable"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
+			if end >= len(line) {
+				value = line[position+1:]
+				position = len(line)
+			} else {
+				unquoted, err := strconv.Unquote(line[position : end+1])
+				if err != nil {
+					unquoted = line[position+1 : end]
+				}
+				value = unquoted
+				position = end + 1
 			}
-		}
-	case strings.Contains(inputWordLower, "crash"):
-		words := []string{"crashed", "crashing", "crash"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
+		} else {
+			start = position
+			for position < len(line) && line[position] != ' ' && line[position] != '\t' {
+				position++
 			}
+			value = line[start:position]
 		}
-	case strings.Contains(inputWordLower, "delet"):
-		words := []string{"deletion", "deleted", "deleting", "deletes", "delete"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+		fields[key] = value
+		lowerKey := strings.ToLower(key)
+		if slices.Contains(jsonLevelKeys, lowerKey) || slices.Contains(jsonMessageKeys, lowerKey) {
+			knownKey = true
 		}
-	case strings.Contains(inputWordLower, "remov"):
-		words := []string{"removing", "removed", "removes", "remove"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+	}
+	if len(fields) < 2 || (!knownKey && len(fields) < 3) {
+		return nil, false
+	}
+	return fields, true
+}
+
+// Формат журнала доступа веб-сервера (nginx log_format или Apache LogFormat)
+type accessLogFormat struct {
+	name  string         // имя формата (combined, common или custom1...)
+	regex *regexp.Regexp // регулярное выражение с именованными группами для каждой переменной формата
+}
+
+// Стандартные форматы nginx и Apache (combined проверяется первым, так как common является его началом)
+var defaultAccessLogFormats = []*accessLogFormat{
+	mustAccessLogFormat("combined", `$remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent "$http_referer" "$http_user_agent"`),
+	mustAccessLogFormat("common", `$remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent`),
+}
+
+// Шаблоны для переменных, значения которых имеют известный вид (остальные читаются до следующего символа формата)
+var accessLogFieldPatterns = map[string]string{
+	"remote_addr":     `[^ ]+`,
+	"time_local":      `[^\]]+`,
+	"status":          `\d{3}`,
+	"body_bytes_sent": `\d+|-`,
+	"bytes_sent":      `\d+|-`,
+	"request_time":    `[\d.]+|-`,
+}
+
+// Синонимы полей журнала доступа для фильтрации (ip=10.0.0.1 найдет поле remote_addr)
+var accessLogFieldAliases = [][]string{
+	{"remote_addr", "ip", "client"},
+	{"request_method", "method"},
+	{"uri", "path", "request_uri"},
+	{"server_protocol", "protocol"},
+	{"body_bytes_sent", "bytes", "size"},
+	{"http_referer", "referer"},
+	{"http_user_agent", "agent", "user_agent"},
+}
+
+// Переменные Apache LogFormat и соответствующие им переменные nginx
+var apacheLogFormatVariables = map[byte]string{
+	'h': "$remote_addr",
+	'a': "$remote_addr",
+	'l': "$remote_ident",
+	'u': "$remote_user",
+	't': "[$time_local]",
+	'r': "$request",
+	's': "$status",
+	'b': "$body_bytes_sent",
+	'B': "$body_bytes_sent",
+	'O': "$bytes_sent",
+	'D': "$request_time_us",
+	'T': "$request_time",
+	'v': "$server_name",
+	'V': "$server_name",
+	'p': "$server_port",
+	'm': "$request_method",
+	'U': "$uri",
+	'q': "$query_string",
+	'H': "$server_protocol",
+}
+
+// Максимальная ширина значения, по которой выравниваются колонки журнала доступа
+const accessLogMaxWidth = 48
+
+// Функция для компиляции стандартного формата журнала доступа
+func mustAccessLogFormat(name string, format string) *accessLogFormat {
+	accessFormat, err := parseAccessLogFormat(name, format)
+	if err != nil {
+		panic(err)
+	}
+	return accessFormat
+}
+
+// Функция для преобразования формата Apache (%h %l %u %t "%r" %>s %b) в формат nginx
+func apacheToNginxFormat(format string) string {
+	var builder strings.Builder
+	for position := 0; position < len(format); position++ {
+		if format[position] != '%' || position+1 >= len(format) {
+			builder.WriteByte(format[position])
+			continue
 		}
-	case strings.Contains(inputWordLower, "stop"):
-		words := []string{"stopping", "stopped", "stoped", "stops", "stop"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+		position++
+		// Модификаторы статуса (%>s и %<s)
+		if format[position] == '>' || format[position] == '<' {
+			position++
+			if position >= len(format) {
 				break
 			}
 		}
-	case strings.Contains(inputWordLower, "invalid"):
-		words := []string{"invalidation", "invalidating", "invalidated", "invalidate", "invalid"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
+		switch {
+		case format[position] == '%':
+			builder.WriteByte('%')
+		// Заголовки запроса и ответа: %{User-Agent}i и %{Content-Type}o
+		case format[position] == '{':
+			end := strings.IndexByte(format[position:], '}')
+			if end == -1 || position+end+1 >= len(format) {
+				builder.WriteString(format[position:])
+				position = len(format)
+				continue
+			}
+			header := strings.ToLower(strings.ReplaceAll(format[position+1:position+end], "-", "_"))
+			position += end + 1
+			switch format[position] {
+			case 'i':
+				builder.WriteString("$http_" + header)
+			case 'o':
+				builder.WriteString("$sent_http_" + header)
+			case 't':
+				builder.WriteString("[$time_local]")
+			default:
+				builder.WriteString("$" + header)
 			}
-		}
-	case strings.Contains(inputWordLower, "abort"):
-		words := []string{"aborted", "aborting", "abort"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
+		default:
+			if variable, ok := apacheLogFormatVariables[format[position]]; ok {
+				builder.WriteString(variable)
+			} else {
+				builder.WriteString("$apache_" + string(format[position]))
 			}
 		}
-	case strings.Contains(inputWordLower, "block"):
-		words := []string{"blocked", "blocker", "blocking", "blocks", "block"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
+	}
+	return builder.String()
+}
+
+// Функция для компиляции формата журнала доступа (log_format nginx или LogFormat Apache) в регулярное выражение
+// Каждая переменная становится именованной группой, пробелы в формате соответствуют одному или нескольким пробелам (для выравнивания)
+func parseAccessLogFormat(name string, format string) (*accessLogFormat, error) {
+	if !strings.Contains(format, "$") && strings.Contains(format, "%") {
+		format = apacheToNginxFormat(format)
+	}
+	var pattern strings.Builder
+	pattern.WriteString(`^\s*`)
+	variables := 0
+	position := 0
+	for position < len(format) {
+		// Текст между переменными
+		if format[position] != '$' {
+			end := strings.IndexByte(format[position:], '$')
+			if end == -1 {
+				end = len(format)
+			} else {
+				end += position
 			}
+			pattern.WriteString(strings.ReplaceAll(regexp.QuoteMeta(format[position:end]), " ", " +"))
+			position = end
+			continue
 		}
-	case strings.Contains(inputWordLower, "activ"):
-		words := []string{"inactive", "deactivated", "deactivating", "deactivate"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+		// Переменная в виде $name или ${name}
+		start := position + 1
+		braces := start < len(format) && format[start] == '{'
+		if braces {
+			start++
 		}
-	case strings.Contains(inputWordLower, "exit"):
-		words := []string{"exited", "exiting", "exits", "exit"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+		end := start
+		for end < len(format) && (format[end] == '_' || format[end] >= 'a' && format[end] <= 'z' || format[end] >= 'A' && format[end] <= 'Z' || format[end] >= '0' && format[end] <= '9') {
+			end++
 		}
-	case strings.Contains(inputWordLower, "crit"):
-		words := []string{"critical", "critic", "crit"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+		variable := format[start:end]
+		if braces && end < len(format) && format[end] == '}' {
+			end++
 		}
-	case strings.Contains(inputWordLower, "fail"):
-		words := []string{"failed", "failure", "failing", "fails", "fail"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+		if variable == "" {
+			pattern.WriteString(`\$`)
+			position++
+			continue
 		}
-	case strings.Contains(inputWordLower, "reject"):
-		words := []string{"rejecting", "rejection", "rejected", "reject"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
+		position = end
+		// Значение переменной читается до следующего символа формата
+		fieldPattern, ok := accessLogFieldPatterns[variable]
+		if !ok {
+			if position < len(format) && format[position] != '$' {
+				fieldPattern = `[^` + regexp.QuoteMeta(format[position:position+1]) + `]*`
+			} else {
+				fieldPattern = `[^ ]*`
 			}
 		}
-	case strings.Contains(inputWordLower, "fatal"):
-		words := []string{"fatality", "fataling", "fatals", "fatal"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+		pattern.WriteString("(?P<" + variable + ">" + fieldPattern + ")")
+		variables++
+	}
+	if variables == 0 {
+		return nil, fmt.Errorf("format %q does not contain variables", format)
+	}
+	regex, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, err
+	}
+	return &accessLogFormat{name: name, regex: regex}, nil
+}
+
+// Функция для поиска формата журнала доступа, которому соответствует строка (возвращает индексы групп)
+func (app *App) matchAccessLog(line string) (*accessLogFormat, []int) {
+	formats := app.accessLogFormats
+	if len(formats) == 0 {
+		formats = defaultAccessLogFormats
+	}
+	for _, format := range formats {
+		if match := format.regex.FindStringSubmatchIndex(line); match != nil {
+			return format, match
 		}
-	case strings.Contains(inputWordLower, "clos"):
-		words := []string{"closed", "closing", "close"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+	}
+	return nil, nil
+}
+
+// Функция для чтения полей строки журнала доступа
+// Из запроса ($request) дополнительно извлекаются метод, путь (без параметров) и протокол
+func (app *App) parseAccessLogFields(line string) (map[string]string, bool) {
+	format, match := app.matchAccessLog(line)
+	if format == nil {
+		return nil, false
+	}
+	fields := make(map[string]string)
+	for i, name := range format.regex.SubexpNames() {
+		if name == "" || match[2*i] < 0 {
+			continue
 		}
-	case strings.Contains(inputWordLower, "drop"):
-		words := []string{"dropped", "droping", "drops", "drop"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
+		fields[name] = line[match[2*i]:match[2*i+1]]
+	}
+	if request, ok := fields["request"]; ok {
+		parts := strings.Fields(request)
+		if len(parts) >= 2 {
+			fields["request_method"] = parts[0]
+			uri, query, _ := strings.Cut(parts[1], "?")
+			fields["uri"] = uri
+			if query != "" {
+				fields["query_string"] = query
 			}
 		}
-	case strings.Contains(inputWordLower, "kill"):
-		words := []string{"killer", "killing", "kills", "kill"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+		if len(parts) >= 3 {
+			fields["server_protocol"] = parts[2]
 		}
-	case strings.Contains(inputWordLower, "cancel"):
-		words := []string{"cancellation", "cancelation", "canceled", "cancelling", "canceling", "cancel"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+	}
+	return fields, true
+}
+
+// Функция для выравнивания колонок в строках журнала доступа
+// Значения дополняются пробелами до самого длинного значения поля с тем же именем во всех форматах (common и combined выравниваются вместе)
+// Значения длиннее accessLogMaxWidth не учитываются
+func (app *App) alignAccessLogLines(lines []string) []string {
+	var formats []*accessLogFormat
+	var matches [][]int
+	widths := make(map[string]int)
+	for i, line := range lines {
+		format, match := app.matchAccessLog(line)
+		if format == nil {
+			continue
 		}
-	case strings.Contains(inputWordLower, "refus"):
-		words := []string{"refusing", "refused", "refuses", "refuse"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
-			}
+		// Создаем массивы только при первой найденной строке журнала доступа
+		if formats == nil {
+			formats = make([]*accessLogFormat, len(lines))
+			matches = make([][]int, len(lines))
 		}
-	case strings.Contains(inputWordLower, "restrict"):
-		words := []string{"restricting", "restricted", "restriction", "restrict"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
+		formats[i] = format
+		matches[i] = match
+		for group, name := range format.regex.SubexpNames() {
+			if group == 0 {
+				continue
 			}
-		}
-	case strings.Contains(inputWordLower, "panic"):
-		words := []string{"panicked", "panics", "panic"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
-				break
+			length := match[2*group+1] - match[2*group]
+			if length <= accessLogMaxWidth && length > widths[name] {
+				widths[name] = length
 			}
 		}
-	case strings.Contains(inputWordLower, "unknown"):
-		coloredWord = app.replaceWordLower(inputWord, "unknown", "\033[31m")
-	case strings.Contains(inputWordLower, "unavailable"):
-		coloredWord = app.replaceWordLower(inputWord, "unavailable", "\033[31m")
-	case strings.Contains(inputWordLower, "unsuccessful"):
-		coloredWord = app.replaceWordLower(inputWord, "unsuccessful", "\033[31m")
-	case strings.Contains(inputWordLower, "found"):
-		coloredWord = app.replaceWordLower(inputWord, "found", "\033[31m")
-	case strings.Contains(inputWordLower, "denied"):
-		coloredWord = app.replaceWordLower(inputWord, "denied", "\033[31m")
-	case strings.Contains(inputWordLower, "conflict"):
-		coloredWord = app.replaceWordLower(inputWord, "conflict", "\033[31m")
-	case strings.Contains(inputWordLower, "false"):
-		coloredWord = app.replaceWordLower(inputWord, "false", "\033[31m")
-	case strings.Contains(inputWordLower, "none"):
-		coloredWord = app.replaceWordLower(inputWord, "none", "\033[31m")
-	case strings.Contains(inputWordLower, "null"):
-		coloredWord = app.replaceWordLower(inputWord, "null", "\033[31m")
-	// Исключения
-	case strings.Contains(inputWordLower, "res"):
-		words := []string{"resolved", "resolving", "resolve", "restarting", "restarted", "restart"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+	}
+	if formats == nil {
+		return lines
+	}
+	alignedLines := make([]string, len(lines))
+	for i, line := range lines {
+		if formats[i] == nil {
+			alignedLines[i] = line
+			continue
 		}
-	// Зеленый (успех) [32m]
-	case strings.Contains(inputWordLower, "succe"):
-		words := []string{"successfully", "successful", "succeeded", "succeed", "success"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+		alignedLines[i] = padAccessLogLine(line, formats[i], matches[i], widths)
+	}
+	return alignedLines
+}
+
+// Функция для добавления пробелов после значений полей (в первом пробеле после значения, чтобы не разрывать кавычки и скобки)
+func padAccessLogLine(line string, format *accessLogFormat, match []int, widths map[string]int) string {
+	var builder strings.Builder
+	last := 0
+	names := format.regex.SubexpNames()
+	// Последнее поле не выравнивается
+	for group := 1; group < len(names)-1; group++ {
+		start, end, nextStart := match[2*group], match[2*group+1], match[2*group+2]
+		if start < 0 || nextStart < end {
+			continue
 		}
-	case strings.Contains(inputWordLower, "complet"):
-		words := []string{"completed", "completing", "completion", "completes", "complete"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+		padding := widths[names[group]] - (end - start)
+		if padding <= 0 {
+			continue
 		}
-	case strings.Contains(inputWordLower, "accept"):
-		words := []string{"accepted", "accepting", "acception", "acceptance", "acceptable", "acceptably", "accepte", "accepts", "accept"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+		space := strings.IndexByte(line[end:nextStart], ' ')
+		if space == -1 {
+			continue
 		}
-	case strings.Contains(inputWordLower, "connect"):
-		words := []string{"connected", "connecting", "connection", "connects", "connect"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+		builder.WriteString(line[last : end+space])
+		builder.WriteString(strings.Repeat(" ", padding))
+		last = end + space
+	}
+	builder.WriteString(line[last:])
+	return builder.String()
+}
+
+// Функция для получения цвета по классу кода ответа HTTP
+func statusColor(status string) string {
+	if len(status) != 3 {
+		return ""
+	}
+	switch status[0] {
+	case '1':
+		return "\033[34m"
+	case '2':
+		return "\033[32m"
+	case '3':
+		return "\033[36m"
+	case '4':
+		return "\033[33m"
+	case '5':
+		return "\033[31m"
+	}
+	return ""
+}
+
+// Функция для получения цвета по уровню журнала (severity)
+// Цвета уровней отличаются от цвета ключей (голубой), чтобы значение level выделялось в строке
+func levelColor(level string) string {
+	switch strings.ToLower(strings.Trim(level, `"'`)) {
+	case "emerg", "emergency", "alert", "crit", "critical", "fatal", "panic", "dpanic", "err", "error", "eror", "e", "f":
+		return "\033[31m"
+	case "warn", "warning", "w":
+		return "\033[33m"
+	case "info", "information", "informational", "notice", "i":
+		return "\033[32m"
+	case "debug", "trace", "verbose", "d":
+		return "\033[90m"
+	}
+	return ""
+}
+
+// Функция для отделения объекта JSON от префикса строки (например, дата и имя процесса в journald или syslog)
+func splitJsonLine(line string) (prefix string, jsonPart string, ok bool) {
+	trimmedLine := strings.TrimSpace(line)
+	if !strings.HasSuffix(trimmedLine, "}") {
+		return "", "", false
+	}
+	index := strings.Index(trimmedLine, "{")
+	if index == -1 {
+		return "", "", false
+	}
+	return trimmedLine[:index], trimmedLine[index:], true
+}
+
+// Функция для чтения объекта JSON в плоский массив полей (вложенные объекты через точку)
+func parseJsonFields(jsonPart string) (map[string]string, bool) {
+	decoder := json.NewDecoder(strings.NewReader(jsonPart))
+	// Сохраняем числа в исходном виде (без перевода в экспоненциальную запись)
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, false
+	}
+	fields := make(map[string]string, len(object))
+	flattenJsonFields("", object, fields)
+	return fields, true
+}
+
+// Функция для рекурсивного заполнения полей из вложенных объектов JSON
+func flattenJsonFields(prefix string, object map[string]interface{}, fields map[string]string) {
+	for key, value := range object {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch typedValue := value.(type) {
+		case map[string]interface{}:
+			flattenJsonFields(key, typedValue, fields)
+		case string:
+			fields[key] = typedValue
+		case nil:
+			fields[key] = "null"
+		case []interface{}:
+			data, _ := json.Marshal(typedValue)
+			fields[key] = string(data)
+		default:
+			fields[key] = fmt.Sprint(typedValue)
 		}
-	case strings.Contains(inputWordLower, "finish"):
-		words := []string{"finished", "finishing", "finish"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+	}
+}
+
+// Функция для извлечения первого найденного поля из списка ключей
+func takeField(fields map[string]string, keys []string) (string, string) {
+	for _, key := range keys {
+		if value, ok := fields[key]; ok {
+			return key, value
 		}
-	case strings.Contains(inputWordLower, "start"):
-		words := []string{"started", "starting", "startup", "start"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+	}
+	return "", ""
+}
+
+// Функция для перевода времени в формате Unix (секунды или миллисекунды) в читаемый вид
+func formatUnixTime(value string) string {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 1e9 {
+		return value
+	}
+	// Миллисекунды
+	if number > 1e12 {
+		number /= 1000
+	}
+	seconds, fraction := math.Modf(number)
+	return time.Unix(int64(seconds), int64(fraction*1e9)).Format("2006-01-02T15:04:05.000Z07:00")
+}
+
+// Функция для форматирования строки JSON в компактный вид: time level msg key=value
+func formatJsonFields(fields map[string]string) string {
+	var parts []string
+	usedKeys := make(map[string]bool)
+	for _, keys := range [][]string{jsonTimeKeys, jsonLevelKeys, jsonMessageKeys} {
+		key, value := takeField(fields, keys)
+		if key == "" {
+			continue
 		}
-	case strings.Contains(inputWordLower, "creat"):
-		words := []string{"created", "creating", "creates", "create"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+		usedKeys[key] = true
+		if slices.Contains(jsonTimeKeys, key) {
+			value = formatUnixTime(value)
 		}
-	case strings.Contains(inputWordLower, "enable"):
-		words := []string{"enabled", "enables", "enable"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+		parts = append(parts, value)
+	}
+	// Остальные поля выводим в формате key=value в алфавитном порядке
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		if !usedKeys[key] {
+			keys = append(keys, key)
 		}
-	case strings.Contains(inputWordLower, "allow"):
-		words := []string{"allowed", "allowing", "allow"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := fields[key]
+		if value == "" || strings.ContainsAny(value, " \t\"=") {
+			value = strconv.Quote(value)
 		}
-	case strings.Contains(inputWordLower, "post"):
-		words := []string{"posting", "posted", "postrouting", "post"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
+		parts = append(parts, key+"="+value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Разобранная строка JSON: поля и преобразованный вид вычисляются один раз для строки журнала
+type jsonLine struct {
+	prefix   string            // префикс строки перед объектом JSON
+	jsonPart string            // объект JSON
+	fields   map[string]string // плоский массив полей (nil, если объект не удалось разобрать)
+	compact  string            // компактный вид (time level msg key=value)
+	expanded string            // развернутый вид (pretty-print)
+	indented bool              // развернутый вид уже вычислен
+}
+
+// Функция для получения разобранной строки JSON из кэша окна (nil, если строка не похожа на JSON)
+func (app *App) parseJsonLine(line string) *jsonLine {
+	if parsed, ok := app.jsonLines[line]; ok {
+		return parsed
+	}
+	prefix, jsonPart, ok := splitJsonLine(line)
+	if !ok {
+		return nil
+	}
+	parsed := &jsonLine{prefix: prefix, jsonPart: jsonPart}
+	if fields, ok := parseJsonFields(jsonPart); ok {
+		parsed.fields = fields
+		parsed.compact = prefix + formatJsonFields(fields)
+	}
+	if app.jsonLines == nil {
+		app.jsonLines = make(map[string]*jsonLine)
+	}
+	app.jsonLines[line] = parsed
+	return parsed
+}
+
+// Функция для преобразования строки в формате JSON в компактный вид или развернутый (pretty-print)
+func (app *App) renderJsonLine(line string) (string, bool) {
+	parsed := app.parseJsonLine(line)
+	if parsed == nil {
+		return line, false
+	}
+	if app.jsonExpandMode {
+		if !parsed.indented {
+			var buffer bytes.Buffer
+			if err := json.Indent(&buffer, []byte(parsed.jsonPart), "", "  "); err == nil {
+				parsed.expanded = parsed.prefix + buffer.String()
 			}
+			parsed.indented = true
 		}
-	case strings.Contains(inputWordLower, "rout"):
-		words := []string{"prerouting", "routing", "routes", "route"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
+		return parsed.expanded, parsed.expanded != ""
+	}
+	return parsed.compact, parsed.fields != nil
+}
+
+// Функция для преобразования всех строк журнала в формате JSON
+// Возвращает исходный массив, если в журнале нет строк JSON
+// В кэше разобранных строк остаются только строки текущего журнала
+func (app *App) renderStructuredLines(lines []string) []string {
+	cache := app.jsonLines
+	app.jsonLines = make(map[string]*jsonLine, len(cache))
+	for _, line := range lines {
+		if parsed, ok := cache[line]; ok {
+			app.jsonLines[line] = parsed
+		}
+	}
+	var renderLines []string
+	for i, line := range lines {
+		renderLine, ok := app.renderJsonLine(line)
+		if !ok {
+			if renderLines != nil {
+				renderLines[i] = line
 			}
+			continue
 		}
-	case strings.Contains(inputWordLower, "forward"):
-		words := []string{"forwarding", "forwards", "forward"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+		// Создаем новый массив только при первой найденной строке JSON
+		if renderLines == nil {
+			renderLines = make([]string, len(lines))
+			copy(renderLines, lines[:i])
 		}
-	case strings.Contains(inputWordLower, "pass"):
-		words := []string{"passed", "passing", "password"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
+		renderLines[i] = renderLine
+	}
+	if renderLines == nil {
+		return lines
+	}
+	return renderLines
+}
+
+// Префикс syslog/journald (дата, имя хоста и процесс), после которого проверяется продолжение многострочной записи
+var syslogPrefixRegex = regexp.MustCompile(`^[A-Z][a-z]{2} +\d{1,2} \d{2}:\d{2}:\d{2}(?:\.\d+)? (\S+ [^ :]+(?:\[\d+\])?): ?`)
+
+// Заголовок исключения Java с полным именем класса (java.lang.IllegalStateException: message), который выводится после сообщения журнала
+var javaExceptionRegex = regexp.MustCompile(`^(?:[a-zA-Z_$][\w$]*\.)+[A-Z][\w$]*(?:Exception|Error|Throwable)\b`)
+
+// Начало строк, которые продолжают многострочную запись (стек вызовов Java, Python и Go)
+var multilinePrefixes = []string{
+	"at ",
+	"Caused by:",
+	"Suppressed:",
+	"... ",
+	"Traceback",
+	"goroutine ",
+	"During handling of the above exception",
+	"The above exception was the direct cause",
+}
+
+// Функция для определения многострочных записей: строки с отступом, стек вызовов Java (исключение, at, Caused by:),
+// Python (Traceback и последняя строка с исключением) и Go (goroutine до пустой строки)
+// Возвращает индекс первой строки записи для каждой строки журнала
+func groupMultilineLogs(lines []string) []int {
+	groupStarts := make([]int, len(lines))
+	var groupSource string
+	var goTrace, pythonTrace, lastIndented bool
+	for i, line := range lines {
+		// Отделяем префикс syslog, продолжение записи должно быть от того же процесса
+		body := line
+		var source string
+		if match := syslogPrefixRegex.FindStringSubmatchIndex(line); match != nil {
+			source = line[match[2]:match[3]]
+			body = line[match[1]:]
+		}
+		trimmed := strings.TrimSpace(body)
+		indented := trimmed != "" && (body[0] == ' ' || body[0] == '\t')
+		continuation := false
+		if i > 0 && source == groupSource {
+			switch {
+			case indented:
+				continuation = true
+			case trimmed == "":
+				// Пустая строка продолжает запись, только если за ней следует стек горутины Go
+				if i+1 < len(lines) {
+					next := lines[i+1]
+					if match := syslogPrefixRegex.FindStringSubmatchIndex(next); match != nil {
+						next = next[match[1]:]
+					}
+					continuation = strings.HasPrefix(next, "goroutine ")
+				}
+			case goTrace:
+				continuation = true
+			// Строка с исключением после стека вызовов Python
+			case pythonTrace && lastIndented:
+				continuation = true
+				pythonTrace = false
+			case javaExceptionRegex.MatchString(trimmed):
+				continuation = true
+			default:
+				for _, prefix := range multilinePrefixes {
+					if strings.HasPrefix(trimmed, prefix) {
+						continuation = true
+						break
+					}
+				}
 			}
 		}
-	case strings.Contains(inputWordLower, "run"):
-		words := []string{"running", "runs", "run"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+		if continuation {
+			groupStarts[i] = groupStarts[i-1]
+		} else {
+			groupStarts[i] = i
+			groupSource = source
+			goTrace, pythonTrace = false, false
 		}
-	case strings.Contains(inputWordLower, "add"):
-		words := []string{"added", "add"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+		switch {
+		case strings.HasPrefix(trimmed, "goroutine "):
+			goTrace = true
+		case strings.HasPrefix(trimmed, "Traceback"):
+			pythonTrace = true
+		case trimmed == "":
+			goTrace = false
 		}
-	case strings.Contains(inputWordLower, "open"):
-		words := []string{"opening", "opened", "open"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
-				break
-			}
+		lastIndented = indented
+	}
+	return groupStarts
+}
+
+// Функция для проверки, свернута ли многострочная запись
+func (app *App) isGroupFolded(key int) bool {
+	return app.foldAllGroups != app.foldedGroups[key]
+}
+
+// Строка вывода журнала с позицией в исходном журнале
+type logLine struct {
+	text     string // строка для вывода до покраски (с выделением найденных совпадений)
+	raw      string // исходная строка журнала
+	source   string // источник журнала (путь к файлу или журнал с типом)
+	index    int    // индекс исходной строки в currentLogLines (-1 для пустой строки в конце вывода)
+	number   int    // номер строки в исходном файле или в загруженном журнале (0 для пустой строки в конце вывода)
+	groupKey int    // номер первой строки многострочной записи в источнике (0 для однострочных записей)
+	suffix   string // пометка, которая добавляется после покраски (количество скрытых или повторяющихся строк, закладка)
+}
+
+// Функция для получения метки времени строки (вычисляется при обращении, что бы не замедлять фильтрацию)
+func (line logLine) timestamp() string {
+	return extractTimestamp(line.raw)
+}
+
+// Функция для формирования вывода с учетом многострочных записей
+// Запись выводится целиком, если хотя бы одна ее строка подошла под фильтр (matchLines равен nil без фильтра),
+// для свернутых записей выводится только первая строка и количество скрытых строк
+func (app *App) groupFilteredLines(logLines []string, highlightLines []string, matchLines []bool) []logLine {
+	groupStarts := groupMultilineLogs(app.currentLogLines)
+	// Количество строк в каждой записи
+	groupSizes := make(map[int]int)
+	for i := range groupStarts {
+		if groupStarts[i] != i {
+			groupSizes[groupStarts[i]]++
+		}
+	}
+	// Записи, в которых найдено совпадение
+	var matchGroups map[int]bool
+	if matchLines != nil {
+		matchGroups = make(map[int]bool)
+		for i, match := range matchLines {
+			if match {
+				matchGroups[groupStarts[i]] = true
+			}
+		}
+	}
+	source := app.logSource()
+	offset := app.sourceLineOffset()
+	outputLines := make([]logLine, 0, len(logLines))
+	for i, line := range logLines {
+		start := groupStarts[i]
+		if matchGroups != nil && !matchGroups[start] {
+			continue
 		}
-	case strings.Contains(inputWordLower, "ok"):
-		coloredWord = app.replaceWordLower(inputWord, "ok", "\033[32m")
-	case strings.Contains(inputWordLower, "available"):
-		coloredWord = app.replaceWordLower(inputWord, "available", "\033[32m")
-	case strings.Contains(inputWordLower, "accessible"):
-		coloredWord = app.replaceWordLower(inputWord, "accessible", "\033[32m")
-	case strings.Contains(inputWordLower, "done"):
-		coloredWord = app.replaceWordLower(inputWord, "done", "\033[32m")
-	case strings.Contains(inputWordLower, "true"):
-		coloredWord = app.replaceWordLower(inputWord, "true", "\033[32m")
-	// Синий (статусы) [36m]
-	case strings.Contains(inputWordLower, "req"):
-		words := []string{"requested", "requests", "request"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		if matchLines != nil && matchLines[i] {
+			line = highlightLines[i]
 		}
-	case strings.Contains(inputWordLower, "reg"):
-		words := []string{"registered", "registeration"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
+		// Записи с одинаковой первой строкой различаются по ее номеру (номер в файле не меняется при обновлении журнала)
+		var key int
+		var suffix string
+		if size := groupSizes[start]; size > 0 {
+			key = offset + app.sourceLineNumber(start)
+			if app.isGroupFolded(key) {
+				if i != start {
+					continue
+				}
+				suffix = " \033[35m[+" + strconv.Itoa(size) + " lines]\033[0m"
 			}
 		}
-	case strings.Contains(inputWordLower, "boot"):
-		words := []string{"reboot", "booting", "boot"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
+		outputLines = append(outputLines, logLine{
+			text:     line,
+			raw:      app.currentLogLines[i],
+			source:   source,
+			index:    i,
+			number:   offset + app.sourceLineNumber(i),
+			groupKey: key,
+			suffix:   suffix,
+		})
+	}
+	return outputLines
+}
+
+// Функция для разбиения многострочных записей на отдельные строки вывода с сохранением принадлежности к группам
+// Пометка строки переносится на последнюю строку
+func splitMultilineGroups(lines []logLine) []logLine {
+	if slices.IndexFunc(lines, func(line logLine) bool { return strings.Contains(line.text, "\n") }) == -1 {
+		return lines
+	}
+	splitLines := make([]logLine, 0, len(lines))
+	for _, line := range lines {
+		parts := strings.Split(line.text, "\n")
+		for j, part := range parts {
+			splitLine := line
+			splitLine.text = part
+			if j != len(parts)-1 {
+				splitLine.suffix = ""
 			}
+			splitLines = append(splitLines, splitLine)
 		}
-	case strings.Contains(inputWordLower, "out"):
-		words := []string{"stdout", "timeout", "output"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+	}
+	return splitLines
+}
+
+// Функция для сворачивания или разворачивания многострочной записи, которая содержит строку вывода
+// Возвращает индекс первой строки записи в выводе или -1, если строка не относится к многострочной записи
+func (app *App) toggleGroupFold(index int) int {
+	if index < 0 || index >= len(app.filteredLines) || app.filteredLines[index].groupKey == 0 {
+		return -1
+	}
+	key := app.filteredLines[index].groupKey
+	if app.foldedGroups == nil {
+		app.foldedGroups = make(map[int]bool)
+	}
+	if app.foldedGroups[key] {
+		delete(app.foldedGroups, key)
+	} else {
+		app.foldedGroups[key] = true
+	}
+	for index > 0 && app.filteredLines[index-1].groupKey == key {
+		index--
+	}
+	return index
+}
+
+// ---------------------------------------- Log analysis ----------------------------------------
+
+// Регулярные выражения для маскирования изменяемых значений в строках журнала
+var (
+	// UUID: 123e4567-e89b-12d3-a456-426614174000
+	uuidRegex = regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`)
+	// Время в начале строки или в записи: YYYY-MM-DDTHH:MM:SS.MS+HH:MM || Mar  1 10:00:00 || 01/Mar/2025:10:00:00 +0300 || HH:MM:SS.MS
+	logTimestampRegex = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?|\b[A-Z][a-z]{2} +\d{1,2} \d{2}:\d{2}:\d{2}(?:\.\d+)?|\b\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2}(?: [+-]\d{4})?|\b\d{2}:\d{2}:\d{2}(?:[.,]\d+)?\b`)
+	// Десятичные числа
+	numberRegex = regexp.MustCompile(`\d+`)
+)
+
+// Функция для маскирования изменяемых значений строки (UUID, время, IP-адреса, шестнадцатеричные и десятичные числа)
+func maskVariableTokens(line string) string {
+	line = uuidRegex.ReplaceAllString(line, "<uuid>")
+	line = logTimestampRegex.ReplaceAllString(line, "<time>")
+	line = ipAddressRegex.ReplaceAllString(line, "<ip>")
+	line = hexByteRegex.ReplaceAllString(line, "<hex>")
+	return numberRegex.ReplaceAllString(line, "<num>")
+}
+
+// Функция для извлечения первой найденной метки времени из строки
+func extractTimestamp(line string) string {
+	return logTimestampRegex.FindString(line)
+}
+
+// Функция для объединения повторяющихся строк подряд (в том числе отличающихся только изменяемыми значениями)
+// в одну строку с количеством повторений, временем первой и последней строки
+// Строки многострочных записей и строки с пометками не объединяются
+func dedupLines(lines []logLine) []logLine {
+	outputLines := make([]logLine, 0, len(lines))
+	// Текущая последовательность повторений: индекс в выводе, количество, строка без покраски, маска и время
+	runIndex := -1
+	var runCount int
+	var runLine, runMask, firstTime, lastTime string
+	finishRun := func() {
+		if runIndex >= 0 && runCount > 1 {
+			suffix := " \033[35m(x" + strconv.Itoa(runCount) + ")\033[0m"
+			if firstTime != "" && lastTime != "" {
+				suffix += " \033[36m[" + firstTime + " - " + lastTime + "]\033[0m"
+			}
+			outputLines[runIndex].suffix = suffix
+		}
+		runIndex = -1
+	}
+	for _, line := range lines {
+		if line.groupKey != 0 || line.suffix != "" {
+			finishRun()
+			outputLines = append(outputLines, line)
+			continue
 		}
-	case strings.Contains(inputWordLower, "put"):
-		words := []string{"input", "put"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		plainLine := line.text
+		if strings.Contains(plainLine, "\x1b[") {
+			plainLine = removeANSI(plainLine)
 		}
-	case strings.Contains(inputWordLower, "get"):
-		words := []string{"getting", "get"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
+		// Маска вычисляется только если строка отличается от предыдущей
+		var mask string
+		if runIndex >= 0 && plainLine != runLine {
+			if runMask == "" {
+				runMask = maskVariableTokens(runLine)
 			}
+			mask = maskVariableTokens(plainLine)
 		}
-	case strings.Contains(inputWordLower, "set"):
-		words := []string{"settings", "setting", "setup", "set"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
+		if runIndex >= 0 && (plainLine == runLine || mask == runMask) {
+			runCount++
+			runLine = plainLine
+			if timestamp := extractTimestamp(plainLine); timestamp != "" {
+				lastTime = timestamp
 			}
+			continue
 		}
-	case strings.Contains(inputWordLower, "head"):
-		words := []string{"headers", "header", "heades", "head"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		finishRun()
+		outputLines = append(outputLines, line)
+		runIndex = len(outputLines) - 1
+		runCount = 1
+		runLine = plainLine
+		runMask = mask
+		firstTime = extractTimestamp(plainLine)
+		lastTime = firstTime
+	}
+	finishRun()
+	return outputLines
+}
+
+// Форматы времени для чтения меток, найденных через logTimestampRegex
+var logTimestampLayouts = []string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"Jan _2 15:04:05",
+	"02/Jan/2006:15:04:05 -0700",
+	"02/Jan/2006:15:04:05",
+	"15:04:05",
+}
+
+// Функция для чтения метки времени строки журнала
+// Для меток без года (syslog) используется текущий год (предыдущий, если метка позже текущего времени), для меток без даты - текущий день
+func parseLogTimestamp(timestamp string) (time.Time, bool) {
+	parsed, yearless, ok := parseTimestampYear(timestamp)
+	if ok && yearless && parsed.After(time.Now().Add(24*time.Hour)) {
+		parsed = parsed.AddDate(-1, 0, 0)
+	}
+	return parsed, ok
+}
+
+// Функция для чтения метки времени с признаком метки без года (syslog), для которой подставлен текущий год
+func parseTimestampYear(timestamp string) (time.Time, bool, bool) {
+	if timestamp == "" {
+		return time.Time{}, false, false
+	}
+	// Приводим дробную часть секунд к точке и убираем двойной пробел в дате syslog (Mar  1)
+	timestamp = strings.Replace(timestamp, ",", ".", 1)
+	timestamp = strings.Join(strings.Fields(timestamp), " ")
+	var fraction time.Duration
+	if index := strings.IndexByte(timestamp, '.'); index != -1 {
+		end := index + 1
+		for end < len(timestamp) && timestamp[end] >= '0' && timestamp[end] <= '9' {
+			end++
+		}
+		if seconds, err := strconv.ParseFloat("0"+timestamp[index:end], 64); err == nil {
+			fraction = time.Duration(seconds * float64(time.Second))
+		}
+		timestamp = timestamp[:index] + timestamp[end:]
+	}
+	now := time.Now()
+	for _, layout := range logTimestampLayouts {
+		parsed, err := time.ParseInLocation(layout, timestamp, time.Local)
+		if err != nil {
+			continue
 		}
-	case strings.Contains(inputWordLower, "log"):
-		words := []string{"logged", "login"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		switch layout {
+		case "Jan _2 15:04:05":
+			return parsed.AddDate(now.Year(), 0, 0).Add(fraction), true, true
+		case "15:04:05":
+			parsed = time.Date(now.Year(), now.Month(), now.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, time.Local)
 		}
-	case strings.Contains(inputWordLower, "load"):
-		words := []string{"overloading", "overloaded", "overload", "uploading", "uploaded", "uploads", "upload", "downloading", "downloaded", "downloads", "download", "loading", "loaded", "load"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		return parsed.Add(fraction), false, true
+	}
+	return time.Time{}, false, false
+}
+
+// Функция для чтения меток времени строк журнала (строки без метки относятся ко времени предыдущей строки)
+// Год меток без года (syslog) определяется по переходу через новый год: последняя такая метка относится к текущему году
+// (к предыдущему, если она позже текущего времени), и год уменьшается, если метка позже следующей за ней метки больше чем на сутки
+func parseLogTimestamps(lines []string) []time.Time {
+	times := make([]time.Time, len(lines))
+	yearless := make([]bool, len(lines))
+	for i, line := range lines {
+		if strings.Contains(line, "\x1b[") {
+			line = removeANSI(line)
+		}
+		times[i], yearless[i], _ = parseTimestampYear(extractTimestamp(line))
+	}
+	years := 0
+	next := time.Now()
+	for i := len(times) - 1; i >= 0; i-- {
+		if !yearless[i] {
+			continue
 		}
-	case strings.Contains(inputWordLower, "read"):
-		words := []string{"reading", "readed", "read"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		current := times[i].AddDate(years, 0, 0)
+		if current.After(next.Add(24 * time.Hour)) {
+			years--
+			current = times[i].AddDate(years, 0, 0)
 		}
-	case strings.Contains(inputWordLower, "patch"):
-		words := []string{"patching", "patched", "patch"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		times[i], next = current, current
+	}
+	for i := 1; i < len(times); i++ {
+		if times[i].IsZero() {
+			times[i] = times[i-1]
 		}
-	case strings.Contains(inputWordLower, "up"):
-		words := []string{"updates", "updated", "updating", "update", "upgrades", "upgraded", "upgrading", "upgrade", "backup", "up"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+	}
+	return times
+}
+
+// Ключевые слова для определения уровня строки журнала
+var severityKeywords = map[string]string{
+	"emerg": "error", "emergency": "error", "alert": "error", "crit": "error", "critical": "error",
+	"fatal": "error", "panic": "error", "err": "error", "error": "error", "errors": "error",
+	"fail": "error", "failed": "error", "failure": "error", "exception": "error",
+	"warn": "warning", "warning": "warning", "warnings": "warning",
+	"info": "info", "notice": "info",
+	"debug": "debug", "trace": "debug",
+}
+
+// Порядок уровней (при нескольких найденных словах выбирается самый важный)
+var severityOrder = []string{"error", "warning", "info", "debug"}
+
+// Функция для определения уровня строки журнала по ключевым словам (в том числе значение поля level в JSON и logfmt)
+// Возвращает пустую строку, если уровень не найден
+func detectSeverity(line string) string {
+	found := len(severityOrder)
+	for _, word := range strings.FieldsFunc(strings.ToLower(line), func(char rune) bool {
+		return char < 'a' || char > 'z'
+	}) {
+		if severity, ok := severityKeywords[word]; ok {
+			found = min(found, slices.Index(severityOrder, severity))
+			if found == 0 {
 				break
 			}
 		}
-	case strings.Contains(inputWordLower, "listen"):
-		words := []string{"listening", "listener", "listen"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+	}
+	if found == len(severityOrder) {
+		return ""
+	}
+	return severityOrder[found]
+}
+
+// Интервал времени панели Timeline
+type timelineBucket struct {
+	start     time.Time // начало интервала
+	total     int       // количество строк
+	errors    int       // количество строк с уровнем error
+	firstLine int       // индекс первой строки интервала в выводе (-1, если строк нет)
+}
+
+// Высота панели Timeline (с рамкой)
+const timelineHeight = 9
+
+// Размеры интервалов в порядке выбора (наименьший, при котором все интервалы помещаются в ширину панели)
+// Если дневные интервалы не помещаются, интервал увеличивается на целое число дней
+var timelineUnits = []time.Duration{time.Minute, time.Hour, 24 * time.Hour}
+
+// Функция для получения номера интервала времени, начиная с интервала метки first
+// Границы интервалов считаются по местному времени: часы от начала часа, дни от полуночи календарного дня
+func timelineIndex(timestamp, first time.Time, unit time.Duration) int {
+	timestamp, first = timestamp.Local(), first.Local()
+	day := 24 * time.Hour
+	if unit >= day {
+		// Номер календарного дня не зависит от перехода на летнее время
+		dayNumber := func(t time.Time) int {
+			return int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / int64(day/time.Second))
+		}
+		return (dayNumber(timestamp) - dayNumber(first)) / int(unit/day)
+	}
+	return int(truncateLocal(timestamp, unit).Sub(truncateLocal(first, unit)) / unit)
+}
+
+// Функция для получения начала интервала времени по местному времени (минута или час)
+func truncateLocal(timestamp time.Time, unit time.Duration) time.Time {
+	if unit == time.Hour {
+		return time.Date(timestamp.Year(), timestamp.Month(), timestamp.Day(), timestamp.Hour(), 0, 0, 0, time.Local)
+	}
+	return timestamp.Truncate(unit)
+}
+
+// Функция для получения начала интервала по его номеру (дневные интервалы начинаются в местную полночь)
+func timelineBucketStart(first time.Time, unit time.Duration, index int) time.Time {
+	first = first.Local()
+	day := 24 * time.Hour
+	if unit >= day {
+		return time.Date(first.Year(), first.Month(), first.Day()+index*int(unit/day), 0, 0, 0, 0, time.Local)
+	}
+	return truncateLocal(first, unit).Add(time.Duration(index) * unit)
+}
+
+// Функция для распределения строк вывода по интервалам времени (строки без метки времени относятся к интервалу предыдущей строки)
+func buildTimeline(lines []string, width int) ([]timelineBucket, time.Duration) {
+	times := parseLogTimestamps(lines)
+	var first, last time.Time
+	for _, lineTime := range times {
+		if lineTime.IsZero() {
+			continue
 		}
-	case strings.Contains(inputWordLower, "launch"):
-		words := []string{"launched", "launching", "launch"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		if first.IsZero() || lineTime.Before(first) {
+			first = lineTime
 		}
-	case strings.Contains(inputWordLower, "chang"):
-		words := []string{"changed", "changing", "change"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
-		}
-	case strings.Contains(inputWordLower, "clea"):
-		words := []string{"cleaning", "cleaner", "clearing", "cleared", "clear"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
-		}
-	case strings.Contains(inputWordLower, "skip"):
-		words := []string{"skipping", "skipped", "skip"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		if lineTime.After(last) {
+			last = lineTime
 		}
-	case strings.Contains(inputWordLower, "miss"):
-		words := []string{"missing", "missed"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+	}
+	if first.IsZero() || width <= 0 {
+		return nil, 0
+	}
+	intervals := func(unit time.Duration) int {
+		return timelineIndex(last, first, unit) + 1
+	}
+	unit := time.Duration(0)
+	for _, candidate := range timelineUnits {
+		if intervals(candidate) <= width {
+			unit = candidate
+			break
 		}
-	case strings.Contains(inputWordLower, "mount"):
-		words := []string{"mountpoint", "mounted", "mounting", "mount"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+	}
+	if unit == 0 {
+		day := timelineUnits[len(timelineUnits)-1]
+		unit = day * time.Duration((intervals(day)+width-1)/width)
+	}
+	buckets := make([]timelineBucket, intervals(unit))
+	for i := range buckets {
+		buckets[i] = timelineBucket{start: timelineBucketStart(first, unit, i), firstLine: -1}
+	}
+	// Все метки находятся между first и last, поэтому каждая строка попадает в один из интервалов
+	for i, lineTime := range times {
+		if lineTime.IsZero() {
+			continue
 		}
-	case strings.Contains(inputWordLower, "auth"):
-		words := []string{"authenticating", "authentication", "authorization"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		index := timelineIndex(lineTime, first, unit)
+		buckets[index].total++
+		if buckets[index].firstLine == -1 || i < buckets[index].firstLine {
+			buckets[index].firstLine = i
 		}
-	case strings.Contains(inputWordLower, "conf"):
-		words := []string{"configurations", "configuration", "configuring", "configured", "configure", "config", "conf"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		if detectSeverity(lines[i]) == "error" {
+			buckets[index].errors++
 		}
-	case strings.Contains(inputWordLower, "option"):
-		words := []string{"options", "option"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+	}
+	return buckets, unit
+}
+
+// Статистика текущего вывода журнала
+type logStats struct {
+	total      int            // количество строк
+	severities map[string]int // количество строк по уровням (error, warning, info, debug и пустой уровень)
+	first      time.Time      // время первой строки
+	last       time.Time      // время последней строки
+	ips        map[string]int // IP-адреса
+	units      map[string]int // процессы и юниты из префикса syslog (sshd[123]:)
+	urls       map[string]int // адреса URL и пути запросов журналов доступа
+	users      map[string]int // известные имена пользователей
+}
+
+// Элемент списка с количеством для вывода статистики
+type countItem struct {
+	name  string
+	count int
+}
+
+// Регулярное выражение для поиска адресов URL
+var urlRegex = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+// Функция для подсчета статистики по строкам вывода журнала
+func (app *App) buildLogStats(lines []string) logStats {
+	stats := logStats{
+		severities: make(map[string]int),
+		ips:        make(map[string]int),
+		units:      make(map[string]int),
+		urls:       make(map[string]int),
+		users:      make(map[string]int),
+	}
+	users := make(map[string]bool, len(app.userNameArray))
+	for _, userName := range app.userNameArray {
+		users[userName] = true
+	}
+	for _, line := range lines {
+		if strings.Contains(line, "\x1b[") {
+			line = removeANSI(line)
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
 		}
-	case strings.Contains(inputWordLower, "writ"):
-		words := []string{"writing", "writed", "write"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
+		stats.total++
+		stats.severities[detectSeverity(line)]++
+		if timestamp, ok := parseLogTimestamp(extractTimestamp(line)); ok {
+			if stats.first.IsZero() || timestamp.Before(stats.first) {
+				stats.first = timestamp
 			}
-		}
-	case strings.Contains(inputWordLower, "sav"):
-		words := []string{"saved", "saving", "save"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
+			if timestamp.After(stats.last) {
+				stats.last = timestamp
 			}
 		}
-	case strings.Contains(inputWordLower, "paus"):
-		words := []string{"paused", "pausing", "pause"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		for _, ip := range ipAddressRegex.FindAllString(line, -1) {
+			// Убираем порт или маску подсети
+			octets := strings.SplitN(strings.FieldsFunc(ip, func(char rune) bool { return char == ':' || char == '/' })[0], ".", 5)
+			stats.ips[strings.Join(octets[:4], ".")]++
 		}
-	case strings.Contains(inputWordLower, "filt"):
-		words := []string{"filtration", "filtr", "filtering", "filtered", "filter"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		for _, url := range urlRegex.FindAllString(line, -1) {
+			stats.urls[strings.TrimRight(url, ".,;)]")]++
 		}
-	case strings.Contains(inputWordLower, "norm"):
-		words := []string{"normal", "norm"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		if fields, ok := app.parseAccessLogFields(line); ok && fields["uri"] != "" {
+			stats.urls[fields["uri"]]++
 		}
-	case strings.Contains(inputWordLower, "noti"):
-		words := []string{"notifications", "notification", "notify", "noting", "notice"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
+		for _, word := range strings.Fields(line) {
+			if syslogUnitRegex.MatchString(word) {
+				stats.units[word[:strings.IndexByte(word, '[')]]++
 			}
-		}
-	case strings.Contains(inputWordLower, "alert"):
-		words := []string{"alerting", "alert"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
+			word = strings.Trim(word, `"'()[]{}<>,;:=`)
+			if users[word] {
+				stats.users[word]++
 			}
 		}
-	case strings.Contains(inputWordLower, "in"):
-		words := []string{"informations", "information", "informing", "informed", "info", "installation", "installed", "installing", "install", "initialization", "initial", "using"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+	}
+	return stats
+}
+
+// Функция для получения элементов с наибольшим количеством (при равенстве по алфавиту)
+func topCounts(counts map[string]int, limit int) []countItem {
+	items := make([]countItem, 0, len(counts))
+	for name, count := range counts {
+		items = append(items, countItem{name: name, count: count})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].count != items[j].count {
+			return items[i].count > items[j].count
 		}
-	case strings.Contains(inputWordLower, "down"):
-		words := []string{"shutdown", "down"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+		return items[i].name < items[j].name
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// Функция для расчета количества строк в минуту между первой и последней меткой времени
+func (stats logStats) linesPerMinute() float64 {
+	if stats.first.IsZero() {
+		return 0
+	}
+	minutes := stats.last.Sub(stats.first).Minutes()
+	if minutes < 1 {
+		minutes = 1
+	}
+	return float64(stats.total) / minutes
+}
+
+// Шаблон сообщений журнала (группа строк, которые отличаются только изменяемыми значениями)
+type logPattern struct {
+	tokens  []string        // слова шаблона, изменяемые значения заменены масками (<num>, <ip>) или <*>
+	count   int             // количество строк
+	first   int             // индекс первой строки
+	last    int             // индекс последней строки
+	buckets []int           // распределение строк по журналу для графика
+	masks   map[string]bool // маскированные строки, отнесенные к шаблону (фильтр по шаблону выбирает строки с этими масками)
+}
+
+// Количество столбцов графика распределения строк шаблона
+const patternSparklineWidth = 20
+
+// Минимальная доля совпадающих слов для объединения строки с шаблоном
+const patternSimilarity = 0.5
+
+// Функция для разбиения строки на слова шаблона с маскированием изменяемых значений и путей
+func patternTokens(line string) []string {
+	if strings.Contains(line, "\x1b[") {
+		line = removeANSI(line)
+	}
+	tokens := strings.Fields(maskVariableTokens(line))
+	for i, token := range tokens {
+		if strings.HasPrefix(token, "/") && strings.Count(token, "/") >= 2 {
+			tokens[i] = "<path>"
 		}
-	case strings.Contains(inputWordLower, "us"):
-		words := []string{"status", "used", "use"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
-				break
-			}
+	}
+	return tokens
+}
+
+// Функция для получения маскированной строки (слова шаблона строки через пробел)
+func patternMask(line string) string {
+	return strings.Join(patternTokens(line), " ")
+}
+
+// Функция для группировки строк журнала в шаблоны (по принципу алгоритма Drain)
+// Строки сравниваются с шаблонами с тем же количеством слов и первым словом,
+// при объединении отличающиеся слова шаблона заменяются на <*>
+// Возвращает шаблоны в порядке уменьшения количества строк
+func buildLogPatterns(lines []string) []*logPattern {
+	patterns, _ := buildLinePatterns(lines)
+	return patterns
+}
+
+// Функция для группировки строк журнала в шаблоны с шаблоном каждой строки (nil для пустых строк)
+func buildLinePatterns(lines []string) ([]*logPattern, []*logPattern) {
+	var patterns []*logPattern
+	linePatterns := make([]*logPattern, len(lines))
+	groups := make(map[string][]*logPattern)
+	// Кэш найденных шаблонов для строк с одинаковой маской
+	cache := make(map[string]*logPattern)
+	for i, line := range lines {
+		tokens := patternTokens(line)
+		if len(tokens) == 0 {
+			continue
 		}
-	case strings.Contains(inputWordLower, "debug"):
-		coloredWord = app.replaceWordLower(inputWord, "debug", "\033[36m")
-	case strings.Contains(inputWordLower, "verbose"):
-		coloredWord = app.replaceWordLower(inputWord, "verbose", "\033[36m")
-	case strings.HasPrefix(inputWordLower, "trace"):
-		coloredWord = app.replaceWordLower(inputWord, "trace", "\033[36m")
-	case strings.HasPrefix(inputWordLower, "protocol"):
-		coloredWord = app.replaceWordLower(inputWord, "protocol", "\033[36m")
-	case strings.Contains(inputWordLower, "level"):
-		coloredWord = app.replaceWordLower(inputWord, "level", "\033[36m")
-	// Голубой (цифры) [34m]
-	// Byte (0x04)
-	case app.hexByteRegex.MatchString(inputWord):
-		coloredWord = app.hexByteRegex.ReplaceAllStringFunc(inputWord, func(match string) string {
-			colored := ""
-			for _, char := range match {
-				if char == 'x' {
-					colored += "\033[35m" + string(char) + "\033[0m"
-				} else {
-					colored += "\033[34m" + string(char) + "\033[0m"
-				}
-			}
-			return colored
-		})
-	// DateTime
-	case app.dateTimeRegex.MatchString(inputWord):
-		coloredWord = app.dateTimeRegex.ReplaceAllStringFunc(inputWord, func(match string) string {
-			colored := ""
-			for _, char := range match {
-				if char == '-' || char == '.' || char == ':' || char == '+' || char == 'T' {
-					// Пурпурный для символов
-					colored += "\033[35m" + string(char) + "\033[0m"
-				} else {
-					// Синий для цифр
-					colored += "\033[34m" + string(char) + "\033[0m"
-				}
-			}
-			return colored
-		})
-	// Time + MAC
-	case app.timeMacAddressRegex.MatchString(inputWord):
-		coloredWord = app.timeMacAddressRegex.ReplaceAllStringFunc(inputWord, func(match string) string {
-			colored := ""
-			for _, char := range match {
-				if char == '-' || char == ':' || char == '.' || char == ',' || char == '+' {
-					colored += "\033[35m" + string(char) + "\033[0m"
-				} else {
-					colored += "\033[34m" + string(char) + "\033[0m"
-				}
-			}
-			return colored
-		})
-	// Date + IP
-	case app.dateIpAddressRegex.MatchString(inputWord):
-		coloredWord = app.dateIpAddressRegex.ReplaceAllStringFunc(inputWord, func(match string) string {
-			colored := ""
-			for _, char := range match {
-				if char == '.' || char == ':' || char == '-' || char == '+' {
-					colored += "\033[35m" + string(char) + "\033[0m"
-				} else {
-					colored += "\033[34m" + string(char) + "\033[0m"
+		maskLine := strings.Join(tokens, " ")
+		pattern, ok := cache[maskLine]
+		if !ok {
+			key := strconv.Itoa(len(tokens)) + " " + tokens[0]
+			bestSimilarity := 0.0
+			for _, candidate := range groups[key] {
+				if similarity := patternSimilarityRatio(candidate.tokens, tokens); similarity > bestSimilarity {
+					pattern, bestSimilarity = candidate, similarity
 				}
 			}
-			return colored
-		})
-	// Percentage (100%)
-	case strings.Contains(inputWordLower, "%"):
-		coloredWord = app.procRegex.ReplaceAllStringFunc(inputWord, func(match string) string {
-			colored := ""
-			for _, char := range match {
-				if char == '%' {
-					colored += "\033[35m" + string(char) + "\033[0m"
-				} else {
-					colored += "\033[34m" + string(char) + "\033[0m"
+			if pattern == nil || bestSimilarity < patternSimilarity {
+				pattern = &logPattern{tokens: tokens, first: i, buckets: make([]int, patternSparklineWidth), masks: make(map[string]bool)}
+				groups[key] = append(groups[key], pattern)
+				patterns = append(patterns, pattern)
+			} else {
+				for j := range pattern.tokens {
+					if pattern.tokens[j] != tokens[j] {
+						pattern.tokens[j] = "<*>"
+					}
 				}
 			}
-			return colored
-		})
-	// tcpdump
-	case strings.Contains(inputWordLower, "tcp"):
-		coloredWord = app.replaceWordLower(inputWord, "tcp", "\033[33m")
-	case strings.Contains(inputWordLower, "udp"):
-		coloredWord = app.replaceWordLower(inputWord, "udp", "\033[33m")
-	case strings.Contains(inputWordLower, "icmp"):
-		coloredWord = app.replaceWordLower(inputWord, "icmp", "\033[33m")
-	case strings.Contains(inputWordLower, "ip"):
-		words := []string{"ip4", "ipv4", "ip6", "ipv6", "ip"}
-		for _, word := range words {
-			if strings.Contains(inputWordLower, word) {
-				coloredWord = app.replaceWordLower(inputWord, word, "\033[33m")
-				break
-			}
+			cache[maskLine] = pattern
+			pattern.masks[maskLine] = true
 		}
-	// Update delimiter
-	case strings.Contains(inputWord, "⎯"):
-		coloredWord = app.replaceWordLower(inputWord, "⎯", "\033[32m")
-	// Исключения
-	case strings.Contains(inputWordLower, "not"):
-		coloredWord = app.replaceWordLower(inputWord, "not", "\033[31m")
+		pattern.count++
+		pattern.last = i
+		pattern.buckets[i*patternSparklineWidth/len(lines)]++
+		linePatterns[i] = pattern
 	}
-	return coloredWord
+	sort.SliceStable(patterns, func(i, j int) bool {
+		return patterns[i].count > patterns[j].count
+	})
+	return patterns, linePatterns
 }
 
-// ---------------------------------------- Log output ----------------------------------------
+// Функция для вычисления доли совпадающих слов строки и шаблона (<*> совпадает с любым словом)
+func patternSimilarityRatio(templateTokens []string, tokens []string) float64 {
+	var equal int
+	for i := range templateTokens {
+		if templateTokens[i] == tokens[i] || templateTokens[i] == "<*>" {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(tokens))
+}
 
-// Функция для обновления вывода журнала (параметр для прокрутки в самый вниз)
-func (app *App) updateLogsView(lowerDown bool) {
-	// Получаем доступ к выводу журнала
-	v, err := app.gui.View("logs")
-	if err != nil {
-		return
+// Функция для построения графика из символов блоков (▁▂▃▄▅▆▇█)
+func sparkline(values []int) string {
+	bars := []rune("▁▂▃▄▅▆▇█")
+	maxValue := slices.Max(values)
+	var builder strings.Builder
+	for _, value := range values {
+		switch {
+		case value == 0 || maxValue == 0:
+			builder.WriteRune(' ')
+		default:
+			builder.WriteRune(bars[(value*len(bars)-1)/maxValue])
+		}
 	}
-	// Очищаем окно для отображения новых строк
-	v.Clear()
-	// Получаем ширину и высоту окна
-	viewWidth, viewHeight := v.Size()
-	// Опускаем в самый низ, только если это не ручной скролл (отключается параметром)
-	if lowerDown {
-		// Если количество строк больше высоты окна, опускаем в самый низ
-		if len(app.filteredLogLines) > viewHeight-1 {
-			app.logScrollPos = len(app.filteredLogLines) - viewHeight - 1
+	return builder.String()
+}
+
+// Функция для форматирования строки шаблона в окне Patterns: количество, график, время первой и последней строки и шаблон
+func (app *App) formatLogPattern(pattern *logPattern) string {
+	firstSeen := extractTimestamp(app.currentLogLines[pattern.first])
+	lastSeen := extractTimestamp(app.currentLogLines[pattern.last])
+	if firstSeen == "" || lastSeen == "" {
+		firstSeen = "line " + strconv.Itoa(pattern.first+1)
+		lastSeen = "line " + strconv.Itoa(pattern.last+1)
+	}
+	var template strings.Builder
+	for i, token := range pattern.tokens {
+		if i > 0 {
+			template.WriteString(" ")
+		}
+		if strings.Contains(token, "<") {
+			template.WriteString("\033[35m" + token + "\033[0m")
 		} else {
-			app.logScrollPos = 0
+			template.WriteString(token)
 		}
 	}
-	// Определяем количество строк для отображения, начиная с позиции logScrollPos
-	startLine := app.logScrollPos
-	endLine := startLine + viewHeight
-	if endLine > len(app.filteredLogLines) {
-		endLine = len(app.filteredLogLines)
+	return fmt.Sprintf("%7d \033[32m%s\033[0m \033[36m%s - %s\033[0m %s", pattern.count, sparkline(pattern.buckets), firstSeen, lastSeen, template.String())
+}
+
+// ---------------------------------------- Bookmarks ----------------------------------------
+
+// Закладка на строке журнала с заметкой
+// Строка определяется по источнику, содержимому, метке времени записи и номеру повтора одинаковых строк с той же меткой, а не по индексу,
+// поэтому закладка сохраняется при обновлении вывода и вставке делимитра
+type bookmark struct {
+	Source     string `json:"source"`
+	Line       string `json:"line"`
+	Timestamp  string `json:"timestamp,omitempty"`
+	Occurrence int    `json:"occurrence,omitempty"`
+	Note       string `json:"note,omitempty"`
+}
+
+// Содержимое файла сессии
+type sessionData struct {
+	Bookmarks []bookmark     `json:"bookmarks"`
+	Layout    *sessionLayout `json:"layout,omitempty"`
+}
+
+// Функция для получения пути к файлу сессии по умолчанию (~/.config/lazyjournal/session.json)
+func defaultSessionFile() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
 	}
-	// Учитываем auto wrap (только в конце лога)
-	if app.logScrollPos == len(app.filteredLogLines)-viewHeight-1 {
-		var viewLines int = 0                             // количество строк для вывода
-		var viewCounter int = 0                           // обратный счетчик видимых строк для остановки
-		var viewIndex int = len(app.filteredLogLines) - 1 // начальный индекс для строк с конца
-		for {
-			// Фиксируем текущую входную строку и счетчик
-			viewLines += 1
-			viewCounter += 1
-			// Получаем длинну видимых символов в строке с конца
-			var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
-			lengthLine := len([]rune(ansiEscape.ReplaceAllString(app.filteredLogLines[viewIndex], "")))
-			// Если длинна строки больше ширины окна, получаем количество дополнительных строк
-			if lengthLine > viewWidth {
-				// Увеличивая счетчик и пропускаем строки
-				viewCounter += lengthLine / viewWidth
-			}
-			// Если счетчик привысил количество видимых строк, вычетаем последнюю строку из видимости
-			if viewCounter > viewHeight {
-				viewLines -= 1
+	return filepath.Join(configDir, "lazyjournal", "session.json")
+}
+
+// Функция для чтения закладок и расположения окон из файла сессии (отсутствие файла не является ошибкой)
+func loadSession(path string) (sessionData, error) {
+	var session sessionData
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return session, nil
+	}
+	if err != nil {
+		return session, err
+	}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return session, err
+	}
+	return session, nil
+}
+
+// Функция для записи закладок и расположения окон в файл сессии
+func saveSession(path string, session sessionData) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Отключаем экранирование символов HTML для читаемости файла
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(session); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data.Bytes(), 0o644)
+}
+
+// Функция для получения содержимого строки без покраски и повторяющихся пробелов (выравнивание столбцов меняется при обновлении)
+func bookmarkLineKey(line string) string {
+	return strings.Join(strings.Fields(removeANSI(line)), " ")
+}
+
+// Функция для получения названия текущего источника журнала (путь к файлу или журнал с типом, для удаленного хоста с префиксом хоста)
+func (app *App) logSource() string {
+	var source string
+	switch app.lastWindow {
+	case "services":
+		source = app.lastSelectUnits + ":" + app.lastSelected
+	case "varLogs":
+		source = app.lastLogPath
+	default:
+		source = app.lastWindow + ":" + app.lastSelected
+	}
+	if app.lastHost != "" {
+		return app.lastHost + ":" + source
+	}
+	return source
+}
+
+// Функция для поиска закладки текущего источника (-1, если закладки нет)
+func (app *App) findBookmark(mark bookmark) int {
+	for i, current := range app.bookmarks {
+		current.Note = ""
+		if current == mark {
+			return i
+		}
+	}
+	return -1
+}
+
+// Функция для получения ключей исходных строк, содержимое которых есть в списке (до позиции end)
+// Метка времени берется из строки, а для строк без метки (продолжения многострочных записей) из предыдущих строк,
+// номер повтора считается для одинаковых строк с одной меткой времени
+func (app *App) bookmarkKeys(texts map[string]bool, end int) map[int]bookmark {
+	keys := make(map[int]bookmark)
+	occurrences := make(map[[2]string]int)
+	timestamp, checked := "", -1
+	for i := 0; i < end && i < len(app.currentLogLines); i++ {
+		if app.isDelimiterLine(i) {
+			continue
+		}
+		text := bookmarkLineKey(app.currentLogLines[i])
+		if !texts[text] {
+			continue
+		}
+		// Ищем последнюю метку времени среди строк после предыдущей проверенной строки
+		for j := i; j > checked; j-- {
+			if app.isDelimiterLine(j) {
+				continue
 			}
-			if viewCounter >= viewHeight {
+			if found := extractTimestamp(app.currentLogLines[j]); found != "" {
+				timestamp = found
 				break
 			}
-			// Уменьшаем индекс
-			viewIndex -= 1
 		}
-		for i := len(app.filteredLogLines) - viewLines - 1; i < endLine; i++ {
-			fmt.Fprintln(v, app.filteredLogLines[i])
+		checked = i
+		key := [2]string{text, timestamp}
+		keys[i] = bookmark{Line: text, Timestamp: timestamp, Occurrence: occurrences[key]}
+		occurrences[key]++
+	}
+	return keys
+}
+
+// Функция для получения закладки строки вывода (false для делимитра, пустых строк и пустой строки в конце вывода)
+func (app *App) outputBookmark(index int) (bookmark, bool) {
+	if index < 0 || index >= len(app.filteredLines) {
+		return bookmark{}, false
+	}
+	source := app.filteredLines[index].index
+	if source < 0 || source >= len(app.currentLogLines) || app.isDelimiterLine(source) {
+		return bookmark{}, false
+	}
+	text := bookmarkLineKey(app.currentLogLines[source])
+	if text == "" {
+		return bookmark{}, false
+	}
+	mark := app.bookmarkKeys(map[string]bool{text: true}, source+1)[source]
+	mark.Source = app.logSource()
+	return mark, true
+}
+
+// Функция для поиска строк вывода с закладками текущего источника и добавления пометки с заметкой
+// Пометка добавляется на последнюю строку вывода исходной строки (развернутый JSON выводится в нескольких строках)
+func (app *App) markBookmarkedLines() {
+	app.bookmarkedLines = nil
+	source := app.logSource()
+	notes := make(map[bookmark]string)
+	texts := make(map[string]bool)
+	for _, mark := range app.bookmarks {
+		if mark.Source == source {
+			note := mark.Note
+			mark.Source, mark.Note = "", ""
+			notes[mark] = note
+			texts[mark.Line] = true
+		}
+	}
+	if len(notes) == 0 {
+		return
+	}
+	keys := app.bookmarkKeys(texts, len(app.currentLogLines))
+	for i, line := range app.filteredLines {
+		if i+1 < len(app.filteredLines) && app.filteredLines[i+1].index == line.index {
+			continue
 		}
-	} else {
-		// Проходим по отфильтрованным строкам и выводим их
-		for i := startLine; i < endLine; i++ {
-			fmt.Fprintln(v, app.filteredLogLines[i])
+		key, ok := keys[line.index]
+		if !ok {
+			continue
 		}
-	}
-	// Вычисляем процент прокрутки и обновляем заголовок
-	var percentage int = 0
-	if len(app.filteredLogLines) > 0 {
-		// Стартовая позиция + размер текущего вывода логов и округляем в большую сторону (math)
-		percentage = int(math.Ceil(float64((startLine+viewHeight)*100) / float64(len(app.filteredLogLines))))
-		if percentage > 100 {
-			v.Title = fmt.Sprintf("Logs: 100%% (%d) ["+app.debugLoadTime+"]", len(app.filteredLogLines)) // "Logs: 100%% (%d) [Max lines: "+app.logViewCount+"/Load time: "+app.debugLoadTime+"]"
+		note, ok := notes[key]
+		if !ok {
+			continue
+		}
+		app.bookmarkedLines = append(app.bookmarkedLines, i)
+		if note != "" {
+			app.filteredLines[i].suffix += " \033[33m[★ " + note + "]\033[0m"
 		} else {
-			v.Title = fmt.Sprintf("Logs: %d%% (%d/%d) ["+app.debugLoadTime+"]", percentage, startLine+1+viewHeight, len(app.filteredLogLines))
+			app.filteredLines[i].suffix += " \033[33m[★]\033[0m"
 		}
-	} else {
-		v.Title = "Logs: 0% (0) [" + app.debugLoadTime + "]"
 	}
-	app.viewScrollLogs(percentage)
 }
 
-// Функция для обновления интерфейса скроллинга
-func (app *App) viewScrollLogs(percentage int) {
-	vScroll, _ := app.gui.View("scrollLogs")
-	vScroll.Clear()
-	// Определяем высоту окна
-	_, viewHeight := vScroll.Size()
-	// Заполняем скролл пробелами, если вывод пустой или не выходит за пределы окна
-	if percentage == 0 || percentage > 100 {
-		fmt.Fprintln(vScroll, "▲")
-		for i := 1; i < viewHeight-1; i++ {
-			fmt.Fprintln(vScroll, " ")
-		}
-		fmt.Fprintln(vScroll, "▼")
+// Функция для добавления или удаления закладки на строке вывода
+func (app *App) toggleBookmark(index int) {
+	mark, ok := app.outputBookmark(index)
+	if !ok {
+		return
+	}
+	if i := app.findBookmark(mark); i != -1 {
+		app.bookmarks = slices.Delete(app.bookmarks, i, i+1)
 	} else {
-		// Рассчитываем позицию курсора (корректируем процент на размер скролла и верхней стрелки)
-		scrollPosition := (viewHeight*percentage)/100 - 3 - 1
-		fmt.Fprintln(vScroll, "▲")
-		// Выводим строки с пробелами и символом █
-	for_scroll:
-		for i := 1; i < viewHeight-3; i++ {
-			// Проверяем текущую поизицию
-			switch {
-			case i == scrollPosition:
-				// Выводим скролл
-				fmt.Fprintln(vScroll, "███")
-			case scrollPosition <= 0 || app.logScrollPos == 0:
-				// Если вышли за пределы окна или текст находится в самом начале, устанавливаем курсор в начало
-				fmt.Fprintln(vScroll, "███")
-				// Остальное заполняем пробелами с учетом стрелки и курсора (-4) до последней стрелки (-1)
-				for i := 4; i < viewHeight-1; i++ {
-					fmt.Fprintln(vScroll, " ")
-				}
-				break for_scroll
-			default:
-				// Пробелы на остальных строках
-				fmt.Fprintln(vScroll, " ")
-			}
-		}
-		fmt.Fprintln(vScroll, "▼")
+		app.bookmarks = append(app.bookmarks, mark)
 	}
+	app.saveSessionFile()
 }
 
-// Функция для скроллинга вниз
-func (app *App) scrollDownLogs(step int) error {
-	v, err := app.gui.View("logs")
+// Функция для изменения заметки на строке вывода (закладка создается, если ее нет)
+func (app *App) setBookmarkNote(index int, note string) {
+	mark, ok := app.outputBookmark(index)
+	if !ok {
+		return
+	}
+	i := app.findBookmark(mark)
+	if i == -1 {
+		app.bookmarks = append(app.bookmarks, mark)
+		i = len(app.bookmarks) - 1
+	}
+	app.bookmarks[i].Note = strings.Join(strings.Fields(note), " ")
+	app.saveSessionFile()
+}
+
+// Функция для чтения закладок и расположения окон из файла сессии при запуске
+// Поврежденный файл не мешает запуску: сессия начинается пустой, а ошибка выводится в заголовке окна журнала
+func (app *App) loadSessionFile() {
+	if app.sessionFile == "" {
+		return
+	}
+	session, err := loadSession(app.sessionFile)
 	if err != nil {
-		return err
+		fmt.Fprintln(os.Stderr, "Warning: session file is not loaded:", err)
+		app.sessionError = "not loaded: " + err.Error()
+		return
 	}
-	// Получаем высоту окна, что бы не опускать лог с пустыми строками
-	_, viewHeight := v.Size()
-	// Проверяем, что размер журнала больше размера окна
-	if len(app.filteredLogLines) > viewHeight {
-		// Увеличиваем позицию прокрутки
-		app.logScrollPos += step
-		// Если достигнут конец списка, останавливаем на максимальной длинне с учетом высоты окна
-		if app.logScrollPos > len(app.filteredLogLines)-1-viewHeight {
-			app.logScrollPos = len(app.filteredLogLines) - 1 - viewHeight
-			// Включаем автоскролл
-			app.autoScroll = true
+	app.bookmarks = session.Bookmarks
+	if session.Layout != nil && slices.Contains(layoutPresets, session.Layout.Preset) {
+		app.layoutPreset = session.Layout.Preset
+		app.listsSize = session.Layout.ListsSize
+	}
+}
+
+// Функция для сохранения закладок и расположения окон в файл сессии (если файл не задан, они хранятся только в памяти)
+func (app *App) saveSessionFile() {
+	if app.sessionFile == "" {
+		return
+	}
+	session := sessionData{Bookmarks: app.bookmarks}
+	// Расположение окон сохраняется, только если оно отличается от расположения по умолчанию
+	if app.layoutPreset != "" && app.layoutPreset != "columns" || app.listsSize != 0 {
+		session.Layout = &sessionLayout{Preset: app.layoutPreset, ListsSize: app.listsSize}
+	}
+	// Ошибка записи выводится в заголовке окна журнала до следующего успешного сохранения
+	app.sessionError = ""
+	if err := saveSession(app.sessionFile, session); err != nil {
+		app.sessionError = "not saved: " + err.Error()
+	}
+}
+
+// Функция для поиска следующей (step > 0) или предыдущей строки с закладкой относительно позиции (-1, если строки нет)
+func (app *App) nearBookmark(position int, step int) int {
+	if step > 0 {
+		for _, index := range app.bookmarkedLines {
+			if index > position {
+				return index
+			}
 		}
-		// Вызываем функцию для обновления отображения журнала
-		app.updateLogsView(false)
+		return -1
 	}
-	return nil
+	for i := len(app.bookmarkedLines) - 1; i >= 0; i-- {
+		if app.bookmarkedLines[i] < position {
+			return app.bookmarkedLines[i]
+		}
+	}
+	return -1
 }
 
-// Функция для скроллинга вверх
-func (app *App) scrollUpLogs(step int) error {
-	app.logScrollPos -= step
-	if app.logScrollPos < 0 {
-		app.logScrollPos = 0
+// ---------------------------------------- Export ----------------------------------------
+
+// Форматы выгрузки текущего вывода журнала и расширения файлов
+var (
+	exportFormats    = []string{"text", "ansi", "html", "json"}
+	exportExtensions = map[string]string{
+		"text": ".log",
+		"ansi": ".ansi.log",
+		"html": ".html",
+		"json": ".jsonl",
+	}
+	exportFormatNames = map[string]string{
+		"text": "Text",
+		"ansi": "ANSI",
+		"html": "HTML",
+		"json": "JSON lines",
 	}
-	// Отключаем автоскролл
-	app.autoScroll = false
-	app.updateLogsView(false)
-	return nil
+)
+
+// Функция для получения имени файла выгрузки по умолчанию (<unit>-<timestamp>.log)
+func (app *App) defaultExportName(format string) string {
+	name := app.lastSelected
+	if app.lastWindow == "varLogs" && app.lastLogPath != "" {
+		name = strings.TrimSuffix(filepath.Base(app.lastLogPath), filepath.Ext(app.lastLogPath))
+	}
+	name = strings.Map(func(char rune) rune {
+		if char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9' || char == '.' || char == '-' || char == '_' {
+			return char
+		}
+		return '_'
+	}, strings.Trim(removeANSI(name), " ."))
+	if name == "" {
+		name = "lazyjournal"
+	}
+	return name + "-" + time.Now().Format("20060102-150405") + exportExtensions[format]
 }
 
-// Функция для переход к началу журнала
-func (app *App) pageUpLogs() {
-	app.logScrollPos = 0
-	app.autoScroll = false
-	app.updateLogsView(false)
+// Функция для проверки наличия структурированных строк (JSON, logfmt или журнал доступа) в текущем выводе
+func (app *App) hasStructuredLines() bool {
+	for _, line := range app.filteredLines {
+		if line.index >= 0 && app.parseLineFields(removeANSI(line.raw)) != nil {
+			return true
+		}
+	}
+	return false
 }
 
-// Функция для очистки поля ввода фильтра
-func (app *App) clearFilterEditor(g *gocui.Gui) {
-	v, _ := g.View("filter")
-	// Очищаем содержимое View
-	v.Clear()
-	// Устанавливаем курсор на начальную позицию
-	if err := v.SetCursor(0, 0); err != nil {
-		return
+// Функция для преобразования исходной строки в объект JSON (исходный объект JSON сохраняется без изменений)
+func (app *App) exportJsonLine(line string) string {
+	line = removeANSI(line)
+	if _, jsonPart, ok := splitJsonLine(line); ok {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, []byte(jsonPart)); err == nil {
+			return compact.String()
+		}
 	}
-	// Очищаем буфер фильтра
-	app.filterText = ""
-	app.applyFilter(false)
+	fields := app.parseLineFields(line)
+	if fields == nil {
+		fields = map[string]string{"message": line}
+	}
+	data, _ := json.Marshal(fields)
+	return string(data)
 }
 
-// Функция для обновления последнего выбранного вывода лога
-func (app *App) updateLogOutput(seconds int) {
-	for {
-		// Выполняем обновление интерфейса через метод Update для иницилизации перерисовки интерфейса
-		app.gui.Update(func(g *gocui.Gui) error {
-			// Сбрасываем автоскролл, если это ручное обновление, что бы опустить журнал вниз
-			if seconds == 0 {
-				app.autoScroll = true
-			}
-			switch app.lastWindow {
-			case "services":
-				app.loadJournalLogs(app.lastSelected, false)
-			case "varLogs":
-				app.loadFileLogs(app.lastSelected, false)
-			}
-			return nil
-		})
-		if seconds == 0 {
-			break
+// Функция для получения текста строк вывода без пометок интерфейса (свертка, повторы, закладки), номеров строк,
+// делимитра обновления и пустой строки в конце
+func (app *App) outputLineTexts() []string {
+	var lines []string
+	for _, line := range app.filteredLines {
+		if line.index < 0 || app.isDelimiterLine(line.index) {
+			continue
 		}
-		time.Sleep(time.Duration(seconds) * time.Second)
+		lines = append(lines, line.text)
 	}
+	return lines
 }
 
-// Функция для обновления вывода при изменение размера окна
-func (app *App) updateWindowSize(seconds int) {
-	for {
-		app.gui.Update(func(g *gocui.Gui) error {
-			v, err := g.View("logs")
-			if err != nil {
-				log.Panicln(err)
+// Функция для покраски строк через tailspin (покраска выполняется на локальном хосте)
+func tailSpinColor(lines []string) ([]string, error) {
+	cmd := localRunner{}.Command("tailspin")
+	// Создаем пайп для передачи данных
+	cmd.Stdin = bytes.NewBufferString(strings.Join(lines, "\n"))
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	err := cmd.Run()
+	return strings.Split(out.String(), "\n"), err
+}
+
+// Функция для формирования содержимого выгрузки текущего вывода журнала в выбранном формате
+// Строки ANSI и HTML красятся в том же режиме, что и вывод на экране
+func (app *App) exportLogLines(format string) []byte {
+	lines := app.outputLineTexts()
+	if app.colorMode && (format == "ansi" || format == "html") {
+		// При ошибке tailspin используется встроенная покраска
+		colored := false
+		if app.tailSpinMode {
+			if colorLines, err := tailSpinColor(lines); err == nil && len(colorLines) >= len(lines) {
+				lines, colored = colorLines[:len(lines)], true
 			}
-			windowWidth, windowHeight := v.Size()
-			if windowWidth != app.windowWidth || windowHeight != app.windowHeight {
-				app.windowWidth, app.windowHeight = windowWidth, windowHeight
-				app.updateLogsView(true)
-				if v, err := g.View("services"); err == nil {
-					_, viewHeight := v.Size()
-					app.maxVisibleServices = viewHeight
+		}
+		if !colored {
+			for i, line := range lines {
+				lines[i] = app.lineColor(line)
+			}
+		}
+	}
+	var output bytes.Buffer
+	switch format {
+	case "ansi":
+		for _, line := range lines {
+			output.WriteString(line + "\n")
+		}
+	case "html":
+		output.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+		output.WriteString("<title>" + html.EscapeString(app.logSource()) + "</title>\n")
+		output.WriteString("<style>body { background: #1e1e1e; color: #d4d4d4; } pre { font-family: monospace; white-space: pre-wrap; }</style>\n")
+		output.WriteString("</head>\n<body>\n<pre>\n")
+		for _, line := range lines {
+			output.WriteString(ansiToHTML(line) + "\n")
+		}
+		output.WriteString("</pre>\n</body>\n</html>\n")
+	case "json":
+		for i, line := range app.filteredLines {
+			// Пропускаем пустую строку в конце, делимитр и повторные строки развернутого JSON
+			if line.index < 0 || app.isDelimiterLine(line.index) || strings.TrimSpace(line.raw) == "" || i > 0 && app.filteredLines[i-1].index == line.index {
+				continue
+			}
+			output.WriteString(app.exportJsonLine(line.raw) + "\n")
+		}
+	default:
+		for _, line := range lines {
+			output.WriteString(removeANSI(line) + "\n")
+		}
+	}
+	return output.Bytes()
+}
+
+// Основные цвета терминала для преобразования в CSS (обычные и яркие)
+var ansiColors = []string{
+	"#000000", "#cd3131", "#0dbc79", "#e5e510", "#2472c8", "#bc3fbc", "#11a8cd", "#e5e5e5",
+	"#666666", "#f14c4c", "#23d18b", "#f5f543", "#3b8eea", "#d670d6", "#29b8db", "#ffffff",
+}
+
+// Функция для получения цвета CSS из палитры 256 цветов
+func ansi256Color(index int) string {
+	switch {
+	case index < 16:
+		return ansiColors[index]
+	case index < 232:
+		index -= 16
+		levels := []int{0, 95, 135, 175, 215, 255}
+		return fmt.Sprintf("#%02x%02x%02x", levels[index/36], levels[index/6%6], levels[index%6])
+	default:
+		gray := 8 + (index-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+// Регулярное выражение для поиска последовательностей SGR (покраска текста)
+var ansiSgrRegex = regexp.MustCompile(`\x1b\[([0-9;]*)m`)
+
+// Функция для преобразования покраски ANSI в строку HTML со стилями CSS
+func ansiToHTML(line string) string {
+	var output, text strings.Builder
+	var foreground, background, style, textStyle string
+	var bold, italic, underline bool
+	// Соседние фрагменты с одинаковым стилем объединяются в один элемент span (покраска может идти по символам)
+	flushText := func() {
+		if text.Len() == 0 {
+			return
+		}
+		if textStyle == "" {
+			output.WriteString(html.EscapeString(text.String()))
+		} else {
+			output.WriteString(`<span style="` + textStyle + `">` + html.EscapeString(text.String()) + "</span>")
+		}
+		text.Reset()
+	}
+	writeText := func(fragment string) {
+		if fragment == "" {
+			return
+		}
+		if style != textStyle {
+			flushText()
+			textStyle = style
+		}
+		text.WriteString(fragment)
+	}
+	position := 0
+	for _, match := range ansiSgrRegex.FindAllStringSubmatchIndex(line, -1) {
+		writeText(line[position:match[0]])
+		position = match[1]
+		codes := strings.Split(line[match[2]:match[3]], ";")
+		for i := 0; i < len(codes); i++ {
+			code, _ := strconv.Atoi(codes[i])
+			switch {
+			case code == 0:
+				foreground, background = "", ""
+				bold, italic, underline = false, false, false
+			case code == 1:
+				bold = true
+			case code == 3:
+				italic = true
+			case code == 4:
+				underline = true
+			case code == 22:
+				bold = false
+			case code == 23:
+				italic = false
+			case code == 24:
+				underline = false
+			case code >= 30 && code <= 37:
+				foreground = ansiColors[code-30]
+			case code >= 90 && code <= 97:
+				foreground = ansiColors[code-90+8]
+			case code == 39:
+				foreground = ""
+			case code >= 40 && code <= 47:
+				background = ansiColors[code-40]
+			case code >= 100 && code <= 107:
+				background = ansiColors[code-100+8]
+			case code == 49:
+				background = ""
+			case code == 38 || code == 48:
+				// Расширенная палитра: 38;5;N или 38;2;R;G;B
+				var color string
+				if i+2 < len(codes) && codes[i+1] == "5" {
+					index, _ := strconv.Atoi(codes[i+2])
+					color = ansi256Color(min(max(index, 0), 255))
+					i += 2
+				} else if i+4 < len(codes) && codes[i+1] == "2" {
+					red, _ := strconv.Atoi(codes[i+2])
+					green, _ := strconv.Atoi(codes[i+3])
+					blue, _ := strconv.Atoi(codes[i+4])
+					color = fmt.Sprintf("#%02x%02x%02x", red&255, green&255, blue&255)
+					i += 4
 				}
-				if v, err := g.View("varLogs"); err == nil {
-					_, viewHeight := v.Size()
-					app.maxVisibleFiles = viewHeight
+				if code == 38 {
+					foreground = color
+				} else {
+					background = color
+				}
+			}
+		}
+		var styles []string
+		if foreground != "" {
+			styles = append(styles, "color: "+foreground)
+		}
+		if background != "" {
+			styles = append(styles, "background: "+background)
+		}
+		if bold {
+			styles = append(styles, "font-weight: bold")
+		}
+		if italic {
+			styles = append(styles, "font-style: italic")
+		}
+		if underline {
+			styles = append(styles, "text-decoration: underline")
+		}
+		style = strings.Join(styles, "; ")
+	}
+	writeText(line[position:])
+	flushText()
+	return output.String()
+}
+
+// ---------------------------------------- Clipboard ----------------------------------------
+
+// Функция для включения режима выделения с первой видимой строки или отмены выделения
+func (app *App) toggleSelection() {
+	app.selectMode = !app.selectMode
+	if app.selectMode {
+		app.selectStart = max(0, min(app.logScrollPos, len(app.filteredLogLines)-2))
+		app.selectEnd = app.selectStart
+	}
+}
+
+// Функция для перемещения конца выделения с прокруткой вывода, что бы строка оставалась видимой
+func (app *App) moveSelection(step int) error {
+	app.selectEnd = max(0, min(app.selectEnd+step, len(app.filteredLogLines)-2))
+	app.autoScroll = false
+	if v, err := app.gui.View(app.logsViewName()); err == nil {
+		_, viewHeight := v.Size()
+		switch {
+		case app.selectEnd < app.logScrollPos:
+			app.logScrollPos = app.selectEnd
+		case app.selectEnd >= app.logScrollPos+viewHeight:
+			app.logScrollPos = min(app.selectEnd-viewHeight+1, max(0, len(app.filteredLogLines)-1-viewHeight))
+		}
+	}
+	app.updateLogsView(false)
+	return nil
+}
+
+// Функция для получения первой и последней выделенной строки (выделение может продолжаться вверх от начальной строки)
+func (pane *logPane) selectedRange() (int, int) {
+	return min(pane.selectStart, pane.selectEnd), max(pane.selectStart, pane.selectEnd)
+}
+
+// Функция для вывода строки с инверсией цвета без покраски, если строка выделена (смена цвета текста в gocui сбрасывает инверсию)
+func (app *App) selectedLineView(index int) string {
+	line := app.filteredLogLines[index]
+	start, end := app.selectedRange()
+	if !app.selectMode || index < start || index > end {
+		return line
+	}
+	return "\033[7m" + removeANSI(line) + "\033[0m"
+}
+
+// Функция для получения текста выделенных строк без покраски и пометок (без выделения копируется первая видимая строка)
+func (app *App) selectedText() string {
+	start, end := app.logScrollPos, app.logScrollPos
+	if app.selectMode {
+		start, end = app.selectedRange()
+	}
+	var lines []string
+	for i := start; i <= end && i < len(app.filteredLines); i++ {
+		lines = append(lines, removeANSI(app.filteredLines[i].text))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Функция для формирования последовательности OSC 52 для копирования в буфер обмена терминала
+// В tmux последовательность передается дважды: для самого tmux (set-clipboard on) и внешнему терминалу через DCS passthrough (allow-passthrough on)
+func osc52Sequence(text string, tmux bool) string {
+	sequence := "\033]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if tmux {
+		return sequence + "\033Ptmux;\033" + sequence + "\033\\"
+	}
+	return sequence
+}
+
+// Функция для получения доступной утилиты копирования в буфер обмена (wl-copy, xclip или pbcopy)
+func clipboardCommand() []string {
+	var commands [][]string
+	switch {
+	case runtime.GOOS == "darwin":
+		commands = append(commands, []string{"pbcopy"})
+	case os.Getenv("WAYLAND_DISPLAY") != "":
+		commands = append(commands, []string{"wl-copy"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		commands = append(commands, []string{"xclip", "-selection", "clipboard"})
+	}
+	for _, command := range commands {
+		if _, err := exec.LookPath(command[0]); err == nil {
+			return command
+		}
+	}
+	return nil
+}
+
+// Функция для копирования текста в буфер обмена через OSC 52 (работает через SSH и tmux) и локальную утилиту, если она доступна
+func copyToClipboard(text string) error {
+	terminal, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		terminal = os.Stdout
+	} else {
+		defer terminal.Close()
+	}
+	_, err = terminal.WriteString(osc52Sequence(text, os.Getenv("TMUX") != ""))
+	if command := clipboardCommand(); command != nil {
+		cmd := exec.Command(command[0], command[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		// Ошибка возвращается, только если текст не удалось передать ни одним из способов
+		if cmdErr := cmd.Run(); cmdErr != nil && err != nil {
+			return cmdErr
+		}
+		return nil
+	}
+	return err
+}
+
+// ---------------------------------------- External viewer ----------------------------------------
+
+// Функция для проверки, что строка является делимитром, который вставлен в журнал при обновлении вывода
+func (app *App) isDelimiterLine(index int) bool {
+	return app.newUpdateIndex > 0 && index == app.newUpdateIndex && index < len(app.currentLogLines) && strings.HasPrefix(app.currentLogLines[index], "⎯")
+}
+
+// Функция для получения порядкового номера исходной строки в загруженном журнале без учета делимитра (с 1)
+func (app *App) sourceLineNumber(index int) int {
+	if app.newUpdateIndex > 0 && index > app.newUpdateIndex && app.isDelimiterLine(app.newUpdateIndex) {
+		return index
+	}
+	return index + 1
+}
+
+// Функция для получения позиции строки вывода с исходной строкой (для пустой строки в конце берется предыдущая строка)
+func (app *App) recordPosition(index int) int {
+	for index = min(index, len(app.filteredLines)-1); index >= 0; index-- {
+		if app.filteredLines[index].index >= 0 {
+			return index
+		}
+	}
+	return -1
+}
+
+// Функция для проверки, что файл журнала читается как текст без преобразования (архивы, pcap и wtmp читаются через внешние утилиты)
+func isPlainLogFile(path string) bool {
+	for _, suffix := range []string{"asl", "pcap", "pcapng", "pflog", ".gz", ".xz", ".bz2", "lastlog", "lastlogin"} {
+		if strings.HasSuffix(path, suffix) {
+			return false
+		}
+	}
+	for _, name := range []string{"wtmp", "utmp", "utx.log", "btmp"} {
+		if strings.Contains(path, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// Функция для проверки, что текущий журнал является текстовым файлом, который можно открыть напрямую
+func (app *App) isPlainFileSource() bool {
+	return app.lastWindow == "varLogs" && app.getOS != "windows" && app.lastHost == "" && isPlainLogFile(app.lastLogPath)
+}
+
+// Функция для определения файла и номера строки для строки вывода
+// Текстовые файлы открываются напрямую на номере строки в файле,
+// для остальных журналов создается временный файл с загруженными строками (temporary равен true)
+func (app *App) externalViewTarget(index int) (path string, line int, temporary bool, err error) {
+	position := app.recordPosition(index)
+	if position == -1 {
+		return "", 0, false, errors.New("no lines to open")
+	}
+	record := app.filteredLines[position]
+	if app.isPlainFileSource() {
+		return app.lastLogPath, max(1, record.number), false, nil
+	}
+	file, err := os.CreateTemp("", "lazyjournal-*.log")
+	if err != nil {
+		return "", 0, false, err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+	for i, sourceLine := range app.currentLogLines {
+		if app.isDelimiterLine(i) || i == len(app.currentLogLines)-1 && sourceLine == "" {
+			continue
+		}
+		if _, err := writer.WriteString(sourceLine + "\n"); err != nil {
+			return "", 0, false, err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return "", 0, false, err
+	}
+	return file.Name(), app.sourceLineNumber(record.index), true, nil
+}
+
+// Функция для получения команды открытия файла на строке: $EDITOR или $PAGER (less по умолчанию) с параметром +<line>
+func externalViewCommand(editor bool, path string, line int) []string {
+	program := os.Getenv("PAGER")
+	if editor {
+		program = os.Getenv("EDITOR")
+		if program == "" {
+			program = os.Getenv("VISUAL")
+		}
+	}
+	command := strings.Fields(program)
+	if len(command) == 0 {
+		command = []string{"less"}
+		if editor {
+			command = []string{"vi"}
+		}
+	}
+	return append(command, "+"+strconv.Itoa(line), path)
+}
+
+// Функция для открытия журнала на первой видимой строке во внешней программе с приостановкой интерфейса
+func (app *App) openExternalView(g *gocui.Gui, editor bool) error {
+	path, line, temporary, err := app.externalViewTarget(app.logScrollPos)
+	if err != nil {
+		return err
+	}
+	if temporary {
+		defer os.Remove(path)
+	}
+	command := externalViewCommand(editor, path, line)
+	gocui.Suspend()
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+	if err := gocui.Resume(); err != nil {
+		return err
+	}
+	return runErr
+}
+
+// ---------------------------------------- Line numbers ----------------------------------------
+
+// Количество строк в файле журнала для вычисления номеров строк (при дозаписи файла считаются только добавленные строки)
+type fileLineCount struct {
+	path     string
+	size     int64
+	modTime  time.Time
+	newlines int
+	lastByte byte
+}
+
+// Функция для подсчета переводов строк в добавленной части файла
+func (count *fileLineCount) update(size int64) error {
+	file, err := os.Open(count.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader := io.NewSectionReader(file, count.size, size-count.size)
+	buffer := make([]byte, 64*1024)
+	for {
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			count.newlines += bytes.Count(buffer[:n], []byte("\n"))
+			count.lastByte = buffer[n-1]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	count.size = size
+	return nil
+}
+
+// Функция для получения количества строк в файле (последняя строка может не заканчиваться переводом строки)
+func (count *fileLineCount) lines() int {
+	if count.size > 0 && count.lastByte != '\n' {
+		return count.newlines + 1
+	}
+	return count.newlines
+}
+
+// Функция для получения количества загруженных строк журнала без делимитра и пустой строки после последнего перевода строки
+func (app *App) loadedLineCount() int {
+	if len(app.currentLogLines) == 0 {
+		return 0
+	}
+	loadedLines := app.sourceLineNumber(len(app.currentLogLines) - 1)
+	if app.currentLogLines[len(app.currentLogLines)-1] == "" {
+		loadedLines--
+	}
+	return loadedLines
+}
+
+// Функция для получения количества незагруженных строк в начале файла (журнал загружается с конца файла через tail)
+// Для остальных журналов номера строк считаются от начала загруженного вывода
+func (app *App) sourceLineOffset() int {
+	if !app.isPlainFileSource() || len(app.currentLogLines) == 0 {
+		return 0
+	}
+	info, err := os.Stat(app.lastLogPath)
+	if err != nil {
+		return 0
+	}
+	// Файл пересчитывается полностью при смене файла, уменьшении размера (ротация) или перезаписи без изменения размера
+	count := &app.fileLines
+	if count.path != app.lastLogPath || info.Size() < count.size || info.Size() == count.size && !info.ModTime().Equal(count.modTime) {
+		*count = fileLineCount{path: app.lastLogPath}
+	}
+	if info.Size() > count.size {
+		if err := count.update(info.Size()); err != nil {
+			*count = fileLineCount{}
+			return 0
+		}
+	}
+	count.modTime = info.ModTime()
+	return max(0, count.lines()-app.loadedLineCount())
+}
+
+// Функция для добавления номеров строк в начало строк вывода
+// Для делимитра и продолжений развернутого JSON номер не выводится, пустая строка в конце вывода остается без изменений
+func (app *App) addLineNumbers() {
+	maxNumber := 0
+	for _, line := range app.filteredLines {
+		maxNumber = max(maxNumber, line.number)
+	}
+	width := len(strconv.Itoa(maxNumber))
+	for i, line := range app.filteredLines {
+		if line.index < 0 || i >= len(app.filteredLogLines) {
+			continue
+		}
+		number := strconv.Itoa(line.number)
+		if app.isDelimiterLine(line.index) || i > 0 && app.filteredLines[i-1].index == line.index {
+			number = ""
+		}
+		app.filteredLogLines[i] = fmt.Sprintf("\033[2m%*s\033[0m ", width, number) + app.filteredLogLines[i]
+	}
+}
+
+// Функция для поиска строки вывода по условию на исходные строки, которые упорядочены по возрастанию
+// Возвращает последнюю строку вывода, для которой условие не выполняется (строка с указанной позицией или предшествующая ей, если она скрыта)
+func (app *App) findLine(after func(line logLine) bool) int {
+	position := sort.Search(len(app.filteredLines), func(i int) bool {
+		return app.filteredLines[i].index < 0 || after(app.filteredLines[i])
+	})
+	position = app.recordPosition(position - 1)
+	// Для развернутого JSON переходим на первую строку объекта
+	for position > 0 && app.filteredLines[position-1].index == app.filteredLines[position].index {
+		position--
+	}
+	return position
+}
+
+// Функция для поиска строки вывода по индексу исходной строки
+func (app *App) findLineByIndex(index int) int {
+	return app.findLine(func(line logLine) bool { return line.index > index })
+}
+
+// Функция для поиска строки вывода по номеру строки в исходном журнале
+func (app *App) findLineByNumber(number int) int {
+	position := app.findLine(func(line logLine) bool { return line.number > number })
+	// Если номер меньше первой загруженной строки, переходим на первую строку
+	if position == -1 && len(app.filteredLines) > 0 && app.filteredLines[0].index >= 0 {
+		return 0
+	}
+	return position
+}
+
+// Функция для прокрутки вывода журнала так, что бы строка оказалась в центре окна
+func (app *App) centerOnLine(index int) {
+	if index < 0 || index >= len(app.filteredLogLines) {
+		return
+	}
+	app.autoScroll = false
+	viewHeight := 0
+	if v, err := app.gui.View(app.logsViewName()); err == nil {
+		_, viewHeight = v.Size()
+	}
+	app.logScrollPos = max(0, min(index-viewHeight/2, len(app.filteredLogLines)-1-viewHeight))
+	app.updateLogsView(false)
+}
+
+// Функция для сброса фильтра и перехода к исходной строке в полном выводе журнала
+func (app *App) showSourceLine(g *gocui.Gui, find func() int) {
+	app.autoScroll = false
+	app.clearFilterEditor(g)
+	app.centerOnLine(find())
+}
+
+// Функция для перехода к первой видимой строке вывода в полном журнале без фильтра
+func (app *App) showInContext(g *gocui.Gui) {
+	position := app.recordPosition(app.logScrollPos)
+	if position == -1 {
+		return
+	}
+	index := app.filteredLines[position].index
+	app.showSourceLine(g, func() int { return app.findLineByIndex(index) })
+}
+
+// ---------------------------------------- Layout ----------------------------------------
+
+// Расположение окна интерфейса (координаты углов рамки)
+type viewRect struct {
+	x0, y0, x1, y1 int
+}
+
+// Настройки расположения окон, которые сохраняются в файле сессии
+type sessionLayout struct {
+	Preset    string `json:"preset"`
+	ListsSize int    `json:"listsSize,omitempty"`
+}
+
+// Варианты расположения окон: списки журналов в левой колонке или под выводом журнала (для узких терминалов)
+var layoutPresets = []string{"columns", "rows"}
+
+// Функция для получения размера панели списков журналов: ширина левой колонки или высота нижней панели
+// По умолчанию четверть ширины или треть высоты интерфейса, размер изменяется клавишами и перетаскиванием границы
+func (app *App) listsPanelSize(maxX, maxY int) int {
+	if app.layoutPreset == "rows" {
+		if app.listsSize == 0 {
+			return maxY / 3
+		}
+		return max(8, min(app.listsSize, maxY-8))
+	}
+	if app.listsSize == 0 {
+		return maxX / 4
+	}
+	return max(10, min(app.listsSize, maxX*3/4))
+}
+
+// Функция для вычисления расположения всех окон интерфейса в зависимости от варианта расположения и полноэкранного режима
+// Граница между панелями (panelBorder) - невидимое окно для изменения размера панели списков мышью
+func (app *App) layoutRects(maxX, maxY int) map[string]viewRect {
+	inputHeight := 3 // высота поля ввода для фильтрации
+	size := app.listsPanelSize(maxX, maxY)
+	rects := make(map[string]viewRect)
+	// Область вывода журнала (вместе с полем фильтра и панелью Timeline)
+	var logsLeft, logsTop, logsBottom int
+	if app.layoutPreset == "rows" {
+		// Вывод журнала сверху, поле фильтра списков и списки журналов рядом друг с другом снизу
+		listsTop := maxY - size
+		panelWidth := maxX / 3
+		rects["panelBorder"] = viewRect{-1, listsTop - 2, maxX, listsTop + 1}
+		rects["filterList"] = viewRect{0, listsTop, maxX - 1, listsTop + inputHeight - 1}
+		rects["services"] = viewRect{0, listsTop + inputHeight, panelWidth - 1, maxY - 1}
+		rects["varLogs"] = viewRect{panelWidth + 1, listsTop + inputHeight, 2*panelWidth - 1, maxY - 1}
+		rects["docker"] = viewRect{2*panelWidth + 1, listsTop + inputHeight, maxX - 1, maxY - 1}
+		logsLeft, logsTop, logsBottom = 0, 0, listsTop-1
+	} else {
+		availableHeight := maxY - inputHeight // общая высота всех трех окон слева
+		panelHeight := availableHeight / 3    // высота каждого окна
+		rects["panelBorder"] = viewRect{size - 2, -1, size + 2, maxY}
+		rects["filterList"] = viewRect{0, 0, size - 1, inputHeight - 1}
+		rects["services"] = viewRect{0, inputHeight, size - 1, inputHeight + panelHeight - 1}
+		rects["varLogs"] = viewRect{0, inputHeight + panelHeight, size - 1, inputHeight + 2*panelHeight - 1}
+		rects["docker"] = viewRect{0, inputHeight + 2*panelHeight, size - 1, maxY - 1}
+		logsLeft, logsTop, logsBottom = size+1, 0, maxY-1
+	}
+	rects["filter"] = viewRect{logsLeft, logsTop, maxX - 1, logsTop + inputHeight - 1}
+	logsTop += inputHeight
+	// В полноэкранном режиме вывод журнала занимает весь интерфейс (остальные окна скрываются)
+	if app.zoomLogs {
+		logsLeft, logsTop, logsBottom = 0, 0, maxY-1
+	}
+	// Панель вкладок (строка без рамки) между полем фильтра и выводом журнала
+	if len(app.tabs) > 0 && !app.zoomLogs {
+		rects["tabs"] = viewRect{logsLeft, logsTop - 1, maxX - 1, logsTop + 1}
+		logsTop++
+	}
+	if app.timelineMode {
+		rects["timeline"] = viewRect{logsLeft, logsTop, maxX - 1, logsTop + timelineHeight - 1}
+		logsTop += timelineHeight
+	}
+	// Скролл справа от вывода журнала (ширина 3) и вывод журнала
+	logsArea := func(logsName, scrollName string, left, right int) {
+		rects[scrollName] = viewRect{right - 2, logsTop, right, logsBottom}
+		rects[logsName] = viewRect{left, logsTop, right - 2, logsBottom}
+	}
+	if app.splitPane == nil {
+		logsArea("logs", "scrollLogs", logsLeft, maxX-1)
+	} else {
+		// В режиме сравнения область делится на два окна, активное окно остается на своей стороне
+		middle := (logsLeft + maxX) / 2
+		sides := [][2]int{{logsLeft, middle - 1}, {middle, maxX - 1}}
+		active, background := sides[app.side], sides[app.splitPane.side]
+		logsArea("logs", "scrollLogs", active[0], active[1])
+		logsArea("logs2", "scrollLogs2", background[0], background[1])
+	}
+	// Невидимое окно для вывода неактивных вкладок при обновлении в фоне (размер совпадает с активным окном)
+	rects["logsTab"], rects["scrollLogsTab"] = rects["logs"], rects["scrollLogs"]
+	return rects
+}
+
+// Функция для изменения размера панели списков журналов с клавиатуры (step > 0 - увеличить)
+func (app *App) resizeListsPanel(g *gocui.Gui, step int) {
+	maxX, maxY := g.Size()
+	if app.layoutPreset != "rows" {
+		step *= 2
+	}
+	app.listsSize = app.listsPanelSize(maxX, maxY) + step
+	app.listsSize = app.listsPanelSize(maxX, maxY)
+	app.saveSessionFile()
+}
+
+// Функция для переключения варианта расположения окон (размер панели списков сбрасывается)
+func (app *App) switchLayoutPreset() {
+	// Пустое значение соответствует расположению по умолчанию (columns)
+	index := max(0, slices.Index(layoutPresets, app.layoutPreset))
+	app.layoutPreset = layoutPresets[(index+1)%len(layoutPresets)]
+	app.listsSize = 0
+	app.saveSessionFile()
+}
+
+// Функция для включения или отключения полноэкранного режима вывода журнала
+func (app *App) toggleZoomLogs(g *gocui.Gui) error {
+	app.zoomLogs = !app.zoomLogs
+	if !app.zoomLogs {
+		return nil
+	}
+	// Скрытые окна не могут быть активными
+	if v := g.CurrentView(); v != nil && v.Name() == "timeline" {
+		return nil
+	}
+	return app.focusView(g, "logs")
+}
+
+// ---------------------------------------- Split view ----------------------------------------
+
+// Функция для получения названия окна вывода журнала
+func (pane *logPane) logsViewName() string {
+	switch {
+	case pane.hidden:
+		return "logsTab"
+	case pane.background:
+		return "logs2"
+	}
+	return "logs"
+}
+
+// Функция для получения названия окна скролла вывода журнала
+func (pane *logPane) scrollViewName() string {
+	switch {
+	case pane.hidden:
+		return "scrollLogsTab"
+	case pane.background:
+		return "scrollLogs2"
+	}
+	return "scrollLogs"
+}
+
+// Функция для обмена состояния активного окна с другим состоянием (вместе с режимом фильтрации)
+// Повторный вызов с тем же состоянием возвращает исходное
+func (app *App) swapPane(pane *logPane) {
+	app.filterMode = app.selectFilterMode
+	app.logPane, *pane = *pane, app.logPane
+	if app.filterMode != "" {
+		app.selectFilterMode = app.filterMode
+	}
+}
+
+// Функция для выполнения действия с состоянием другого окна или вкладки (загрузка, фильтрация и вывод выполняются в его окно)
+func (app *App) withPane(pane *logPane, action func()) {
+	if pane == nil {
+		return
+	}
+	app.swapPane(pane)
+	defer app.swapPane(pane)
+	action()
+}
+
+// Функция для выполнения действия с состоянием второго окна (вывод в окно logs2)
+func (app *App) withSplitPane(action func()) {
+	app.withPane(app.splitPane, action)
+}
+
+// Функция для вывода текста и режима фильтра активного окна в поле фильтра
+func (app *App) showPaneFilter(g *gocui.Gui) {
+	v, err := g.View("filter")
+	if err != nil {
+		return
+	}
+	v.Clear()
+	fmt.Fprint(v, app.filterText)
+	_ = v.SetCursor(utf8.RuneCountInString(app.filterText), 0)
+	switch app.selectFilterMode {
+	case "fuzzy":
+		v.Title = "Filter (Fuzzy)"
+	case "regex":
+		v.Title = "Filter (Regex)"
+	default:
+		v.Title = "Filter (Default)"
+	}
+}
+
+// Функция для открытия второго окна вывода журнала с копией текущего источника, фильтра и позиции или его закрытия
+func (app *App) toggleSplitView(g *gocui.Gui) error {
+	if app.splitPane != nil {
+		app.splitPane.stopStream()
+		app.splitPane = nil
+		app.side = 0
+		app.syncScroll = false
+	} else {
+		pane := app.logPane
+		pane.background = true
+		pane.side = 1
+		pane.filterMode = app.selectFilterMode
+		pane.selectMode = false
+		// Срезы и карта копируются, что бы окна не изменяли данные друг друга
+		pane.currentLogLines = slices.Clone(app.currentLogLines)
+		pane.filteredLogLines = slices.Clone(app.filteredLogLines)
+		pane.filteredLines = slices.Clone(app.filteredLines)
+		pane.bookmarkedLines = slices.Clone(app.bookmarkedLines)
+		pane.foldedGroups = maps.Clone(app.foldedGroups)
+		// Второе окно запускает свой поток журнала пода при обновлении
+		pane.stream = nil
+		app.splitPane = &pane
+	}
+	app.zoomLogs = false
+	app.redrawLogPanes(g)
+	return app.focusView(g, "logs")
+}
+
+// Функция для переключения активного окна вывода журнала в режиме сравнения
+// Окна остаются на своих местах, поле фильтра отображает фильтр нового активного окна
+func (app *App) switchLogPane(g *gocui.Gui) error {
+	if app.splitPane == nil {
+		return nil
+	}
+	app.swapPane(app.splitPane)
+	app.background = false
+	app.splitPane.background = true
+	app.showPaneFilter(g)
+	app.redrawLogPanes(g)
+	return app.focusView(g, "logs")
+}
+
+// Функция для включения или отключения синхронной прокрутки второго окна по времени строк
+func (app *App) toggleSyncScroll() {
+	if app.splitPane == nil {
+		return
+	}
+	app.syncScroll = !app.syncScroll
+	app.updateLogsView(false)
+}
+
+// Функция для перерисовки окон вывода журнала после изменения их размеров
+// Размеры окон меняются при следующем вызове layout, поэтому вывод обновляется отдельным событием
+func (app *App) redrawLogPanes(g *gocui.Gui) {
+	g.Update(func(g *gocui.Gui) error {
+		app.withSplitPane(func() {
+			app.updateLogsView(app.autoScroll)
+		})
+		app.updateLogsView(app.autoScroll)
+		if v, err := g.View("logs"); err == nil {
+			app.windowWidth, app.windowHeight = v.Size()
+		}
+		return nil
+	})
+}
+
+// Функция для получения времени первой строки с меткой времени, начиная с индекса строки вывода
+func lineTimeFrom(lines []logLine, index int) (time.Time, bool) {
+	for i := max(0, index); i < len(lines); i++ {
+		if timestamp, ok := parseLogTimestamp(lines[i].timestamp()); ok {
+			return timestamp, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Функция для поиска первой строки вывода, время которой не раньше указанного (-1, если такой строки нет)
+// Строки журнала упорядочены по времени, поэтому используется бинарный поиск (строки без времени относятся к следующей строке со временем)
+func findLineByTime(lines []logLine, target time.Time) int {
+	index := sort.Search(len(lines), func(i int) bool {
+		timestamp, ok := lineTimeFrom(lines, i)
+		return !ok || !timestamp.Before(target)
+	})
+	for ; index < len(lines); index++ {
+		if _, ok := parseLogTimestamp(lines[index].timestamp()); ok {
+			return index
+		}
+	}
+	return -1
+}
+
+// Функция для прокрутки второго окна к строке со временем первой видимой строки активного окна
+func (app *App) syncSplitPane() {
+	target, ok := lineTimeFrom(app.filteredLines, app.logScrollPos)
+	if !ok {
+		return
+	}
+	app.withSplitPane(func() {
+		index := findLineByTime(app.filteredLines, target)
+		if index == -1 {
+			// Все строки второго окна раньше, опускаем вывод в конец
+			index = len(app.filteredLogLines) - 1
+		}
+		app.scrollToLine(index)
+	})
+}
+
+// ---------------------------------------- Tabs ----------------------------------------
+
+// Функция для получения названия вкладки по источнику журнала
+func (pane *logPane) tabName() string {
+	name := removeANSI(pane.lastSelected)
+	if name == "" {
+		return "empty"
+	}
+	if utf8.RuneCountInString(name) > 24 {
+		name = string([]rune(name)[:23]) + "…"
+	}
+	return name
+}
+
+// Функция для получения подписи вкладки в панели вкладок (закрепленные для оповещений вкладки отмечены флажком)
+func (pane *logPane) tabLabel(index int) string {
+	if pane.pinned {
+		return fmt.Sprintf(" %d:%s ⚑ ", index+1, pane.tabName())
+	}
+	return fmt.Sprintf(" %d:%s ", index+1, pane.tabName())
+}
+
+// Функция для проверки, что в окне открыт указанный источник указанного хоста
+func (pane *logPane) isSource(host, window, selectUnits, name string) bool {
+	return pane.lastHost == host && pane.lastWindow == window && pane.lastSelected == name && (window != "services" || pane.lastSelectUnits == selectUnits)
+}
+
+// Функция для открытия источника во вкладке: переключение на вкладку с этим источником или создание новой
+// Пустая вкладка используется повторно, новая вкладка наследует текст и режим фильтра текущей
+func (app *App) openTab(window, name string) {
+	if len(app.tabs) == 0 {
+		app.tabs = []*logPane{{}}
+		app.activeTab = 0
+	}
+	if app.lastWindow == "" || app.isSource(app.host, window, app.selectUnits, name) {
+		return
+	}
+	for i, tab := range app.tabs {
+		if i != app.activeTab && tab.isSource(app.host, window, app.selectUnits, name) {
+			app.switchTab(i)
+			return
+		}
+	}
+	app.tabs = append(app.tabs, &logPane{
+		hidden:     true,
+		autoScroll: true,
+		filterText: app.filterText,
+		filterMode: app.selectFilterMode,
+	})
+	app.switchTab(len(app.tabs) - 1)
+}
+
+// Функция для переключения активной вкладки (состояние текущей вкладки сохраняется, счетчик новых ошибок сбрасывается)
+func (app *App) switchTab(index int) {
+	if index < 0 || index >= len(app.tabs) || index == app.activeTab {
+		return
+	}
+	// Расположение окна не зависит от вкладки
+	background, side := app.background, app.side
+	tab := app.tabs[index]
+	app.swapPane(tab)
+	tab.hidden, tab.background = true, false
+	app.tabs[app.activeTab], app.tabs[index] = tab, app.tabs[app.activeTab]
+	app.hidden, app.background, app.side = false, background, side
+	app.newErrors = 0
+	app.activeTab = index
+	app.showActiveTab()
+}
+
+// Функция для переключения на предыдущую или следующую вкладку по кругу (step: 1 - следующая, -1 - предыдущая)
+func (app *App) nextTab(step int) {
+	if len(app.tabs) < 2 {
+		return
+	}
+	app.switchTab((app.activeTab + step + len(app.tabs)) % len(app.tabs))
+}
+
+// Функция для перемещения активной вкладки влево или вправо (step: -1 или 1)
+func (app *App) moveTab(step int) {
+	index := app.activeTab + step
+	if index < 0 || index >= len(app.tabs) {
+		return
+	}
+	app.tabs[app.activeTab], app.tabs[index] = app.tabs[index], app.tabs[app.activeTab]
+	app.activeTab = index
+	app.drawTabs()
+}
+
+// Функция для закрытия активной вкладки (активной становится соседняя вкладка, последняя вкладка очищается)
+func (app *App) closeTab() {
+	if len(app.tabs) <= 1 {
+		app.stopStream()
+		app.tabs = nil
+		app.activeTab = 0
+		app.logPane = logPane{
+			background: app.background,
+			side:       app.side,
+			autoScroll: true,
+			filterText: app.filterText,
+		}
+		app.showActiveTab()
+		return
+	}
+	index := app.activeTab
+	next := index + 1
+	if next == len(app.tabs) {
+		next = index - 1
+	}
+	app.switchTab(next)
+	app.tabs[index].stopStream()
+	app.tabs = slices.Delete(app.tabs, index, index+1)
+	if app.activeTab > index {
+		app.activeTab--
+	}
+	app.drawTabs()
+}
+
+// Функция для вывода состояния активной вкладки (поле фильтра, вывод журнала и панель вкладок)
+func (app *App) showActiveTab() {
+	if app.noGui() {
+		return
+	}
+	app.showPaneFilter(app.gui)
+	app.updateLogsView(app.autoScroll)
+	app.drawTabs()
+}
+
+// Функция для получения индексов строк источника журнала (без делимитра обновления и пустых строк в конце)
+func sourceLineIndexes(lines []string) []int {
+	indexes := make([]int, 0, len(lines))
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "⎯") {
+			indexes = append(indexes, i)
+		}
+	}
+	for len(indexes) > 0 && strings.TrimSpace(lines[indexes[len(indexes)-1]]) == "" {
+		indexes = indexes[:len(indexes)-1]
+	}
+	return indexes
+}
+
+// Функция для получения индекса первой новой строки после обновления журнала
+// Источник дописывается в конец, а загружается только окно последних строк, поэтому ищется наименьший сдвиг,
+// при котором строки предыдущей загрузки совпадают с началом текущей (повторяющиеся строки не сдвигают границу)
+// Если совпадение не найдено (журнал перезаписан или ротирован), новыми считаются все строки
+func newLinesStart(previous, current []string) int {
+	previousIndexes, currentIndexes := sourceLineIndexes(previous), sourceLineIndexes(current)
+	for shift := range previousIndexes {
+		count := len(previousIndexes) - shift
+		if count > len(currentIndexes) {
+			continue
+		}
+		match := true
+		for i := range count {
+			if previous[previousIndexes[shift+i]] != current[currentIndexes[i]] {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if count == len(currentIndexes) {
+			return len(current)
+		}
+		return currentIndexes[count]
+	}
+	return 0
+}
+
+// Функция для подсчета новых строк с ошибками после обновления журнала
+func countNewErrors(previous, current []string) int {
+	count := 0
+	for _, line := range current[newLinesStart(previous, current):] {
+		if detectSeverity(line) == "error" {
+			count++
+		}
+	}
+	return count
+}
+
+// Функция для обновления неактивных вкладок в фоне (вывод в невидимое окно logsTab)
+func (app *App) reloadTabs() {
+	for i, tab := range app.tabs {
+		if i == app.activeTab {
+			continue
+		}
+		app.withPane(tab, func() {
+			previous := app.currentLogLines
+			app.reloadLogPane()
+			app.newErrors += countNewErrors(previous, app.currentLogLines)
+			if app.pinned {
+				app.checkAlerts(previous)
+			}
+		})
+	}
+	app.drawTabs()
+}
+
+// Функция для вывода панели вкладок (активная вкладка выделена, у неактивных выводится количество новых ошибок)
+func (app *App) drawTabs() {
+	if app.noGui() {
+		return
+	}
+	v, err := app.gui.View("tabs")
+	if err != nil {
+		return
+	}
+	v.Clear()
+	app.tabBarEnds = app.tabBarEnds[:0]
+	position := 0
+	for i, tab := range app.tabs {
+		label := tab.tabLabel(i)
+		if i == app.activeTab {
+			label = app.tabLabel(i)
+			fmt.Fprint(v, "\033[30;42m"+label+"\033[0m")
+		} else {
+			fmt.Fprint(v, label)
+			if tab.newErrors > 0 {
+				badge := fmt.Sprintf("(%d) ", tab.newErrors)
+				fmt.Fprint(v, "\033[31m"+badge+"\033[0m")
+				label += badge
+			}
+		}
+		position += utf8.RuneCountInString(label)
+		app.tabBarEnds = append(app.tabBarEnds, position)
+		fmt.Fprint(v, " ")
+		position++
+	}
+}
+
+// Функция для выбора вкладки нажатием мыши на ее название в панели вкладок
+func (app *App) clickTab(g *gocui.Gui) error {
+	x0, _, _, _, err := g.ViewPosition("tabs")
+	if err != nil {
+		return err
+	}
+	mouseX, _ := g.MousePosition()
+	column := mouseX - x0 - 1
+	for i, end := range app.tabBarEnds {
+		if column < end {
+			app.switchTab(i)
+			break
+		}
+	}
+	return app.focusView(g, "logs")
+}
+
+// ---------------------------------------- Diff ----------------------------------------
+
+// Строка, которая есть только в одном из сравниваемых журналов
+type diffLine struct {
+	line  string // первая строка журнала с этим содержимым
+	count int    // количество строк, которые отличаются только изменяемыми значениями
+}
+
+// Разница количества строк шаблона в сравниваемых журналах
+type patternDiff struct {
+	tokens      []string // слова шаблона
+	left, right int      // количество строк шаблона в каждом журнале
+}
+
+// Функция для получения разницы количества строк шаблона без учета знака
+func (pattern patternDiff) delta() int {
+	return max(pattern.left, pattern.right) - min(pattern.left, pattern.right)
+}
+
+// Результат сравнения двух журналов
+type logDiff struct {
+	onlyLeft  []diffLine    // строки только в первом (активном) журнале
+	onlyRight []diffLine    // строки только во втором журнале
+	patterns  []patternDiff // шаблоны с разным количеством строк (в порядке уменьшения разницы)
+}
+
+// Функция для приведения строки к виду для сравнения (изменяемые значения заменены масками, пробелы схлопнуты)
+func normalizeDiffLine(line string) string {
+	if strings.Contains(line, "\x1b[") {
+		line = removeANSI(line)
+	}
+	return strings.Join(strings.Fields(maskVariableTokens(line)), " ")
+}
+
+// Функция для сравнения двух журналов без учета меток времени, PID, адресов и других изменяемых значений
+// Шаблоны строятся по строкам обоих журналов, что бы количество строк каждого шаблона можно было сравнить
+func diffLogs(left, right []string) logDiff {
+	var diff logDiff
+	// Количество строк и первая строка для каждой нормализованной строки в порядке появления
+	collect := func(lines []string) (map[string]*diffLine, []string) {
+		counts := make(map[string]*diffLine)
+		var order []string
+		for _, line := range lines {
+			key := normalizeDiffLine(line)
+			if key == "" {
+				continue
+			}
+			if item, ok := counts[key]; ok {
+				item.count++
+				continue
+			}
+			counts[key] = &diffLine{line: line, count: 1}
+			order = append(order, key)
+		}
+		return counts, order
+	}
+	leftCounts, leftOrder := collect(left)
+	rightCounts, rightOrder := collect(right)
+	for _, key := range leftOrder {
+		if _, ok := rightCounts[key]; !ok {
+			diff.onlyLeft = append(diff.onlyLeft, *leftCounts[key])
+		}
+	}
+	for _, key := range rightOrder {
+		if _, ok := leftCounts[key]; !ok {
+			diff.onlyRight = append(diff.onlyRight, *rightCounts[key])
+		}
+	}
+	// Количество строк каждого общего шаблона в каждом журнале
+	combined := make([]string, 0, len(left)+len(right))
+	combined = append(append(combined, left...), right...)
+	patterns, linePatterns := buildLinePatterns(combined)
+	counts := make(map[*logPattern]*patternDiff)
+	for i, pattern := range linePatterns {
+		if pattern == nil {
+			continue
+		}
+		item, ok := counts[pattern]
+		if !ok {
+			item = &patternDiff{tokens: pattern.tokens}
+			counts[pattern] = item
+		}
+		if i < len(left) {
+			item.left++
+		} else {
+			item.right++
+		}
+	}
+	for _, pattern := range patterns {
+		if item := counts[pattern]; item.left != item.right {
+			diff.patterns = append(diff.patterns, *item)
+		}
+	}
+	sort.SliceStable(diff.patterns, func(i, j int) bool {
+		return diff.patterns[i].delta() > diff.patterns[j].delta()
+	})
+	return diff
+}
+
+// Функция для получения строк загруженного журнала без делимитра обновления
+func (app *App) loadedLines() []string {
+	lines := make([]string, 0, len(app.currentLogLines))
+	for i, line := range app.currentLogLines {
+		if !app.isDelimiterLine(i) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Функция для получения журнала для сравнения с активным: второе окно режима сравнения или соседняя вкладка
+// (вкладка слева, для первой вкладки - справа)
+func (app *App) diffTarget() *logPane {
+	switch {
+	case app.splitPane != nil:
+		return app.splitPane
+	case len(app.tabs) < 2:
+		return nil
+	case app.activeTab > 0:
+		return app.tabs[app.activeTab-1]
+	}
+	return app.tabs[1]
+}
+
+// Функция для форматирования результата сравнения для окна Diff
+func formatLogDiff(diff logDiff, leftName, rightName string) []string {
+	text := []string{fmt.Sprintf("\033[31mOnly in %s\033[0m (%d):", leftName, len(diff.onlyLeft))}
+	for _, item := range diff.onlyLeft {
+		text = append(text, fmt.Sprintf("\033[31m- %5d\033[0m %s", item.count, removeANSI(item.line)))
+	}
+	text = append(text, "", fmt.Sprintf("\033[32mOnly in %s\033[0m (%d):", rightName, len(diff.onlyRight)))
+	for _, item := range diff.onlyRight {
+		text = append(text, fmt.Sprintf("\033[32m+ %5d\033[0m %s", item.count, removeANSI(item.line)))
+	}
+	text = append(text, "", fmt.Sprintf("\033[36mTemplate count differences\033[0m (%d): %s, %s, difference, template", len(diff.patterns), leftName, rightName))
+	for _, item := range diff.patterns {
+		text = append(text, fmt.Sprintf("  %7d %7d \033[33m%+7d\033[0m %s", item.left, item.right, item.right-item.left, strings.Join(item.tokens, " ")))
+	}
+	return text
+}
+
+// Функция для вывода окна со сравнением активного журнала со вторым окном или соседней вкладкой
+func (app *App) showDiff(g *gocui.Gui) {
+	var text []string
+	title := " Diff "
+	if target := app.diffTarget(); target == nil {
+		text = []string{"Open two logs in tabs or in split view (F6) to compare them."}
+	} else {
+		left, leftName := app.loadedLines(), app.tabName()
+		var right []string
+		var rightName string
+		app.withPane(target, func() {
+			right, rightName = app.loadedLines(), app.tabName()
+		})
+		title = fmt.Sprintf(" Diff: %s vs %s ", leftName, rightName)
+		text = formatLogDiff(diffLogs(left, right), leftName, rightName)
+	}
+	app.diffLines = len(text)
+	maxX, maxY := g.Size()
+	diffView, err := g.SetView("diff", 4, 2, maxX-5, maxY-3, 0)
+	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+		return
+	}
+	diffView.Title = title
+	diffView.Highlight = true
+	diffView.Wrap = false
+	diffView.Autoscroll = false
+	diffView.FrameColor = gocui.ColorGreen
+	diffView.TitleColor = gocui.ColorGreen
+	diffView.SelBgColor = gocui.ColorGreen
+	diffView.SelFgColor = gocui.ColorBlack
+	diffView.Clear()
+	for _, line := range text {
+		fmt.Fprintln(diffView, line)
+	}
+	_ = diffView.SetOrigin(0, 0)
+	_ = diffView.SetCursor(0, 0)
+	if _, err := g.SetCurrentView("diff"); err != nil {
+		return
+	}
+}
+
+// Функция для закрытия окна сравнения и возврата к выводу журнала
+func (app *App) closeDiff(g *gocui.Gui) error {
+	if err := g.DeleteView("diff"); err != nil {
+		return err
+	}
+	if _, err := g.SetCurrentView("logs"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ---------------------------------------- Alerts ----------------------------------------
+
+// Правило оповещения: регулярное выражение и уровень важности совпадения
+type alertRule struct {
+	severity string
+	pattern  *regexp.Regexp
+}
+
+// Срабатывание правила оповещения на новой строке журнала
+type alertHit struct {
+	time     time.Time
+	source   string
+	severity string
+	pattern  string
+	line     string
+}
+
+// Уровни правил оповещений и их срочность для notify-send
+var alertUrgency = map[string]string{"error": "critical", "warning": "normal", "info": "low"}
+
+// Цвета рамки окна вывода при срабатывании правила
+var alertColors = map[string]gocui.Attribute{"error": gocui.ColorRed, "warning": gocui.ColorYellow, "info": gocui.ColorCyan}
+
+// Максимальное количество хранимых срабатываний (старые срабатывания удаляются)
+const maxAlertHits = 1000
+
+// Функция для разбора правила оповещения в формате severity:regex (без уровня используется warning)
+func parseAlertRule(text string) (alertRule, error) {
+	severity := "warning"
+	if prefix, pattern, found := strings.Cut(text, ":"); found {
+		if _, ok := alertUrgency[strings.ToLower(prefix)]; ok {
+			severity, text = strings.ToLower(prefix), pattern
+		}
+	}
+	if strings.TrimSpace(text) == "" {
+		return alertRule{}, errors.New("empty regular expression")
+	}
+	pattern, err := regexp.Compile(text)
+	if err != nil {
+		return alertRule{}, err
+	}
+	return alertRule{severity: severity, pattern: pattern}, nil
+}
+
+// Функция для проверки новых строк журнала окна по правилам оповещений после обновления вывода
+// Первая загрузка журнала не проверяется, для строки учитывается первое совпавшее правило
+func (app *App) checkAlerts(previous []string) {
+	if len(app.alertRules) == 0 || len(previous) == 0 {
+		return
+	}
+	var hits []alertHit
+	for i := newLinesStart(previous, app.currentLogLines); i < len(app.currentLogLines); i++ {
+		line := strings.TrimSpace(removeANSI(app.currentLogLines[i]))
+		if line == "" || app.isDelimiterLine(i) {
+			continue
+		}
+		for _, rule := range app.alertRules {
+			if rule.pattern.MatchString(line) {
+				hits = append(hits, alertHit{
+					time:     time.Now(),
+					source:   removeANSI(app.lastSelected),
+					severity: rule.severity,
+					pattern:  rule.pattern.String(),
+					line:     line,
+				})
+				break
+			}
+		}
+	}
+	if len(hits) == 0 {
+		return
+	}
+	app.alertHits = append(app.alertHits, hits...)
+	if over := len(app.alertHits) - maxAlertHits; over > 0 {
+		app.alertHits = slices.Delete(app.alertHits, 0, over)
+	}
+	app.alertsUnseen += len(hits)
+	app.raiseAlert(hits)
+}
+
+// Функция для оповещения о срабатываниях: подсветка рамки окна вывода, звуковой сигнал терминала (отключается флагом --no-alert-bell) и уведомление notify-send
+// Уровень оповещения определяется самым важным срабатыванием
+func (app *App) raiseAlert(hits []alertHit) {
+	if app.noGui() {
+		return
+	}
+	top := hits[0]
+	for _, hit := range hits[1:] {
+		if slices.Index(severityOrder, hit.severity) < slices.Index(severityOrder, top.severity) {
+			top = hit
+		}
+	}
+	app.flashAlertFrame(top.severity)
+	if !app.noAlertBell {
+		fmt.Fprint(os.Stdout, "\a")
+	}
+	if _, err := exec.LookPath("notify-send"); err != nil {
+		return
+	}
+	body := top.line
+	if len(hits) > 1 {
+		body += fmt.Sprintf("\n(+%d more)", len(hits)-1)
+	}
+	cmd := exec.Command("notify-send", "-u", alertUrgency[top.severity], "-a", "lazyjournal", "lazyjournal: "+top.source, body)
+	if err := cmd.Start(); err == nil {
+		go func() {
+			_ = cmd.Wait()
+		}()
+	}
+}
+
+// Функция для подсветки рамки окна вывода журнала цветом уровня оповещения на одну секунду
+// Подсвечивается окно вывода журнала, в котором сработало оповещение (закрепленный журнал во втором окне подсвечивает logs2),
+// для фоновой вкладки подсвечивается основное окно вывода
+func (app *App) flashAlertFrame(severity string) {
+	views := []string{"logs", "scrollLogs"}
+	if app.background {
+		views = []string{"logs2", "scrollLogs2"}
+	}
+	for _, name := range views {
+		if v, err := app.gui.View(name); err == nil {
+			v.FrameColor, v.TitleColor = alertColors[severity], alertColors[severity]
+		}
+	}
+	go func() {
+		time.Sleep(time.Second)
+		app.gui.Update(func(g *gocui.Gui) error {
+			// Восстанавливаем цвет рамки в зависимости от текущего окна (второе окно вывода не принимает ввод)
+			color := gocui.ColorDefault
+			if v := g.CurrentView(); v != nil && v.Name() == views[0] {
+				color = gocui.ColorGreen
+			}
+			for _, name := range views {
+				if v, err := g.View(name); err == nil {
+					v.FrameColor, v.TitleColor = color, color
+				}
+			}
+			return nil
+		})
+	}()
+}
+
+// Функция для закрепления активного журнала, чтобы его новые строки проверялись по правилам оповещений в фоне
+func (app *App) togglePinned() {
+	app.pinned = !app.pinned
+	app.updateLogsView(false)
+	app.drawTabs()
+}
+
+// Функция для вывода окна с правилами оповещений и срабатываниями (сначала правила, затем срабатывания от новых к старым)
+func (app *App) showAlerts(g *gocui.Gui) {
+	app.alertsUnseen = 0
+	maxX, maxY := g.Size()
+	alertsView, err := g.SetView("alerts", 4, 2, maxX-5, maxY-3, 0)
+	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+		return
+	}
+	alertsView.Title = fmt.Sprintf(" Alerts (%d rules, %d hits): a - add the filter text as a rule, d - delete ", len(app.alertRules), len(app.alertHits))
+	alertsView.Highlight = true
+	alertsView.Wrap = false
+	alertsView.Autoscroll = false
+	alertsView.FrameColor = gocui.ColorGreen
+	alertsView.TitleColor = gocui.ColorGreen
+	alertsView.SelBgColor = gocui.ColorGreen
+	alertsView.SelFgColor = gocui.ColorBlack
+	alertsView.Clear()
+	colors := map[string]string{"error": "\033[31m", "warning": "\033[33m", "info": "\033[36m"}
+	for _, rule := range app.alertRules {
+		fmt.Fprintf(alertsView, " rule     %s%-7s\033[0m %s\n", colors[rule.severity], rule.severity, rule.pattern.String())
+	}
+	for i := len(app.alertHits) - 1; i >= 0; i-- {
+		hit := app.alertHits[i]
+		fmt.Fprintf(alertsView, " %s %s%-7s\033[0m \033[90m%s\033[0m %s\n", hit.time.Format("15:04:05"), colors[hit.severity], hit.severity, hit.source, hit.line)
+	}
+	_, originY := alertsView.Origin()
+	_, cursorY := alertsView.Cursor()
+	if originY+cursorY >= len(app.alertRules)+len(app.alertHits) {
+		_ = alertsView.SetOrigin(0, 0)
+		_ = alertsView.SetCursor(0, 0)
+	}
+	if _, err := g.SetCurrentView("alerts"); err != nil {
+		return
+	}
+}
+
+// Функция для добавления текста фильтра в качестве правила оповещения (в формате severity:regex)
+func (app *App) addAlertRule(g *gocui.Gui, v *gocui.View) error {
+	rule, err := parseAlertRule(app.filterText)
+	if err != nil {
+		v.Title = " Alerts: " + err.Error() + " "
+		return nil
+	}
+	app.alertRules = append(app.alertRules, rule)
+	app.showAlerts(g)
+	return nil
+}
+
+// Функция для удаления выбранного правила или срабатывания из окна Alerts
+func (app *App) deleteAlertItem(g *gocui.Gui, v *gocui.View) error {
+	_, originY := v.Origin()
+	_, cursorY := v.Cursor()
+	index := originY + cursorY
+	switch {
+	case index < len(app.alertRules):
+		app.alertRules = slices.Delete(app.alertRules, index, index+1)
+	case index < len(app.alertRules)+len(app.alertHits):
+		hitIndex := len(app.alertHits) - 1 - (index - len(app.alertRules))
+		app.alertHits = slices.Delete(app.alertHits, hitIndex, hitIndex+1)
+	default:
+		return nil
+	}
+	app.showAlerts(g)
+	return nil
+}
+
+// Функция для закрытия окна оповещений и возврата к выводу журнала
+func (app *App) closeAlerts(g *gocui.Gui) error {
+	if err := g.DeleteView("alerts"); err != nil {
+		return err
+	}
+	app.updateLogsView(false)
+	if _, err := g.SetCurrentView("logs"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ---------------------------------------- Watch ----------------------------------------
+
+// Конфигурация режима наблюдения (lazyjournal watch -config rules.yml)
+type watchConfig struct {
+	Interval time.Duration `yaml:"interval"` // интервал проверки источников (5s)
+	Lines    int           `yaml:"lines"`    // количество загружаемых строк журнала (5000)
+	Sink     watchSink     `yaml:"sink"`
+	Sources  []watchSource `yaml:"sources"`
+	Rules    []*watchRule  `yaml:"rules"`
+}
+
+// Приемник срабатываний правил: stdout (по умолчанию), файл или webhook (JSON для каждого срабатывания)
+type watchSink struct {
+	Type string `yaml:"type"`
+	Path string `yaml:"path"`
+	URL  string `yaml:"url"`
+}
+
+// Источник журнала для наблюдения (указывается один из вариантов, host - удаленный хост для чтения через ssh)
+// Для контейнера system - система контейнеризации (docker по умолчанию, podman или kubectl с путем namespace/pod/container)
+type watchSource struct {
+	Unit      string `yaml:"unit"`
+	File      string `yaml:"file"`
+	Container string `yaml:"container"`
+	System    string `yaml:"system"`
+	Host      string `yaml:"host"`
+}
+
+// Правило режима наблюдения: строка совпадает, если подходит под include и severity и не подходит под exclude
+// Правило с порогом срабатывает, когда количество совпадений одного источника за окно времени превышает порог
+type watchRule struct {
+	Name      string        `yaml:"name"`
+	Source    string        `yaml:"source"`   // имя юнита или путь к файлу (пусто - все источники)
+	Include   string        `yaml:"include"`  // регулярное выражение для совпадения
+	Exclude   string        `yaml:"exclude"`  // регулярное выражение для исключения
+	Severity  string        `yaml:"severity"` // уровень строки (error/warning/info/debug)
+	Threshold int           `yaml:"threshold"`
+	Window    time.Duration `yaml:"window"` // окно времени для порога (1m)
+
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+	hits    map[string][]time.Time // время совпадений в окне по хостам и источникам
+}
+
+// Срабатывание правила режима наблюдения
+type watchMatch struct {
+	Time     string `json:"time"`
+	Rule     string `json:"rule"`
+	Host     string `json:"host,omitempty"`
+	Source   string `json:"source"`
+	Severity string `json:"severity,omitempty"`
+	Line     string `json:"line"`
+	Count    int    `json:"count,omitempty"`
+	Window   string `json:"window,omitempty"`
+}
+
+// Функция для чтения и проверки конфигурации режима наблюдения
+func loadWatchConfig(path string) (*watchConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &watchConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil {
+		return nil, err
+	}
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+	if config.Lines <= 0 {
+		config.Lines = 5000
+	}
+	switch config.Sink.Type {
+	case "", "stdout":
+		config.Sink.Type = "stdout"
+	case "file":
+		if config.Sink.Path == "" {
+			return nil, errors.New("sink: path is required for the file sink")
+		}
+	case "webhook":
+		if config.Sink.URL == "" {
+			return nil, errors.New("sink: url is required for the webhook sink")
+		}
+	default:
+		return nil, fmt.Errorf("sink: unknown type %q (stdout, file or webhook)", config.Sink.Type)
+	}
+	if len(config.Sources) == 0 {
+		return nil, errors.New("sources: at least one source is required")
+	}
+	for i, source := range config.Sources {
+		count := 0
+		for _, value := range []string{source.Unit, source.File, source.Container} {
+			if value != "" {
+				count++
+			}
+		}
+		if count != 1 {
+			return nil, fmt.Errorf("sources[%d]: one of unit, file or container is required", i)
+		}
+		switch {
+		case source.System != "" && source.Container == "":
+			return nil, fmt.Errorf("sources[%d]: system is only used with container", i)
+		case source.System == "" && source.Container != "":
+			config.Sources[i].System = "docker"
+		case source.System != "" && !slices.Contains([]string{"docker", "podman", "kubectl"}, source.System):
+			return nil, fmt.Errorf("sources[%d]: unknown system %q (docker, podman or kubectl)", i, source.System)
+		}
+	}
+	if len(config.Rules) == 0 {
+		return nil, errors.New("rules: at least one rule is required")
+	}
+	for i, rule := range config.Rules {
+		if rule.Name == "" {
+			rule.Name = "rule" + strconv.Itoa(i+1)
+		}
+		if rule.Include == "" && rule.Severity == "" {
+			return nil, fmt.Errorf("rules[%d]: include or severity is required", i)
+		}
+		if rule.Severity != "" && !slices.Contains(severityOrder, rule.Severity) {
+			return nil, fmt.Errorf("rules[%d]: unknown severity %q", i, rule.Severity)
+		}
+		if rule.include, err = compileWatchPattern(rule.Include); err != nil {
+			return nil, fmt.Errorf("rules[%d]: include: %w", i, err)
+		}
+		if rule.exclude, err = compileWatchPattern(rule.Exclude); err != nil {
+			return nil, fmt.Errorf("rules[%d]: exclude: %w", i, err)
+		}
+		if rule.Threshold > 0 && rule.Window <= 0 {
+			rule.Window = time.Minute
+		}
+		rule.hits = make(map[string][]time.Time)
+	}
+	return config, nil
+}
+
+// Функция для компиляции необязательного регулярного выражения правила (nil для пустой строки)
+func compileWatchPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile(pattern)
+}
+
+// Функция для проверки новой строки источника по правилу
+// Правило без порога срабатывает на каждую строку, с порогом - при превышении порога (счетчик окна сбрасывается)
+func (rule *watchRule) match(host, source, line string, now time.Time) (watchMatch, bool) {
+	if rule.Source != "" && rule.Source != source {
+		return watchMatch{}, false
+	}
+	severity := detectSeverity(line)
+	if rule.Severity != "" && severity != rule.Severity {
+		return watchMatch{}, false
+	}
+	if rule.include != nil && !rule.include.MatchString(line) {
+		return watchMatch{}, false
+	}
+	if rule.exclude != nil && rule.exclude.MatchString(line) {
+		return watchMatch{}, false
+	}
+	match := watchMatch{
+		Time:     now.Format(time.RFC3339),
+		Rule:     rule.Name,
+		Host:     host,
+		Source:   source,
+		Severity: severity,
+		Line:     line,
+	}
+	if rule.Threshold <= 0 {
+		return match, true
+	}
+	key := host + ":" + source
+	hits := append(rule.hits[key], now)
+	// Удаляем совпадения, которые вышли за окно времени
+	for len(hits) > 0 && now.Sub(hits[0]) >= rule.Window {
+		hits = hits[1:]
+	}
+	if len(hits) <= rule.Threshold {
+		rule.hits[key] = hits
+		return watchMatch{}, false
+	}
+	rule.hits[key] = nil
+	match.Count, match.Window = len(hits), rule.Window.String()
+	return match, true
+}
+
+// Функция для создания состояния источника наблюдения (загрузка выполняется теми же функциями, что и в интерфейсе)
+func newWatchPane(source watchSource) *logPane {
+	if source.Unit != "" {
+		return &logPane{lastWindow: "services", lastSelectUnits: "services", lastSelected: source.Unit, lastHost: source.Host}
+	}
+	if source.Container != "" {
+		return &logPane{
+			lastWindow:                 "docker",
+			lastSelected:               source.Container,
+			lastContainerizationSystem: source.System,
+			lastContainerId:            source.Container,
+			lastHost:                   source.Host,
+		}
+	}
+	return &logPane{lastWindow: "varLogs", lastSelected: source.File, lastLogPath: source.File, lastHost: source.Host}
+}
+
+// Функция для загрузки новых строк источников и проверки их по правилам
+// Первая загрузка источника не проверяется, что бы не выводить срабатывания по уже существующим строкам
+// Ошибка источника возвращается один раз до ее изменения или восстановления источника (строки сохраняются до успешной загрузки)
+func (app *App) pollWatchSources(config *watchConfig, panes []*logPane, now time.Time) ([]watchMatch, []error) {
+	var matches []watchMatch
+	var errs []error
+	for _, pane := range panes {
+		app.withPane(pane, func() {
+			previous := app.currentLogLines
+			failed := app.loadError
+			app.reloadLogPane()
+			if app.loadError != nil {
+				if failed == nil || failed.Error() != app.loadError.Error() {
+					errs = append(errs, fmt.Errorf("%s: %w", app.lastSelected, app.loadError))
+				}
+				app.currentLogLines = previous
+				return
+			}
+			// Строки первой загрузки не проверяются, после нее все строки пустого источника считаются новыми
+			if !app.loaded {
+				app.loaded = true
+				return
+			}
+			for _, line := range app.currentLogLines[newLinesStart(previous, app.currentLogLines):] {
+				line = strings.TrimSpace(removeANSI(line))
+				if line == "" {
+					continue
+				}
+				for _, rule := range config.Rules {
+					if match, ok := rule.match(app.lastHost, app.lastSelected, line, now); ok {
+						matches = append(matches, match)
+					}
+				}
+			}
+		})
+	}
+	return matches, errs
+}
+
+// Функция для отправки срабатывания в приемник (строка JSON в stdout или файл, POST запрос для webhook)
+func (sink watchSink) send(match watchMatch) error {
+	data, err := json.Marshal(match)
+	if err != nil {
+		return err
+	}
+	switch sink.Type {
+	case "file":
+		file, err := os.OpenFile(sink.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = file.Write(append(data, '\n'))
+		return err
+	case "webhook":
+		client := &http.Client{Timeout: 10 * time.Second}
+		response, err := client.Post(sink.URL, "application/json", bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		if response.StatusCode >= 300 {
+			return fmt.Errorf("webhook returned status %s", response.Status)
+		}
+		return nil
+	}
+	_, err = fmt.Println(string(data))
+	return err
+}
+
+// Функция для запуска режима наблюдения без интерфейса (возвращает код завершения)
+func runWatch(args []string) int {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	configPath := flags.String("config", "", "Rules file")
+	flags.StringVar(configPath, "c", "", "Rules file")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *configPath == "" {
+		fmt.Fprintln(os.Stderr, "Usage: lazyjournal watch -config <rules.yml>")
+		return 2
+	}
+	config, err := loadWatchConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error config:", err)
+		return 1
+	}
+	// Загрузчики журналов работают без интерфейса, их ошибки выводятся режимом наблюдения
+	log.SetOutput(io.Discard)
+	app := &App{
+		headless:     true,
+		getOS:        runtime.GOOS,
+		logViewCount: strconv.Itoa(config.Lines),
+	}
+	var panes []*logPane
+	for _, source := range config.Sources {
+		if source.Host != "" {
+			if err := app.connectHost(source.Host, false); err != nil {
+				fmt.Fprintln(os.Stderr, "Error connecting to host:", err)
+				return 1
+			}
+		}
+		panes = append(panes, newWatchPane(source))
+	}
+	// Все источники должны открываться при запуске, последующие ошибки выводятся без завершения
+	if _, errs := app.pollWatchSources(config, panes, time.Now()); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Fprintln(os.Stderr, "Error source:", err)
+		}
+		return 1
+	}
+	for {
+		time.Sleep(config.Interval)
+		matches, errs := app.pollWatchSources(config, panes, time.Now())
+		for _, err := range errs {
+			fmt.Fprintln(os.Stderr, "Error source:", err)
+		}
+		for _, match := range matches {
+			if err := config.Sink.send(match); err != nil {
+				fmt.Fprintln(os.Stderr, "Error sink:", err)
+			}
+		}
+	}
+}
+
+// ---------------------------------------- Command runners ----------------------------------------
+
+// Функция для получения пути к файлу со списком удаленных хостов (~/.config/lazyjournal/hosts)
+func defaultHostsFile() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "lazyjournal", "hosts")
+}
+
+// Функция для чтения списка хостов: один хост на строке, пустые строки и комментарии (#) пропускаются
+// Отсутствие файла не является ошибкой
+func loadHosts(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var hosts []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		if host := strings.TrimSpace(line); host != "" && !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+// Функция для получения параметров ssh: все команды одного хоста выполняются через общее соединение,
+// которое сохраняется 10 минут после завершения последней команды
+// Во время работы интерфейса ssh не должен запрашивать пароль (interactive только для подключения до запуска интерфейса)
+func sshOptions(interactive bool) []string {
+	options := []string{
+		"-o", "ControlMaster=auto",
+		"-o", "ControlPath=" + filepath.Join(os.TempDir(), "lazyjournal-ssh-%C"),
+		"-o", "ControlPersist=10m",
+	}
+	if !interactive {
+		options = append(options, "-o", "BatchMode=yes", "-o", "ConnectTimeout=10")
+	}
+	return options
+}
+
+// Функция для экранирования аргумента команды для удаленной оболочки
+func shellQuote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_@%+=:,./-") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// Исполнитель команд для загрузки списков и журналов
+// Реализации: локальный хост, удаленный хост через ssh, контейнер или под через docker/podman/kubectl exec
+type CommandRunner interface {
+	Command(name string, args ...string) *exec.Cmd
+}
+
+// Выполнение команд на локальном хосте
+type localRunner struct{}
+
+func (localRunner) Command(name string, args ...string) *exec.Cmd {
+	return exec.Command(name, args...)
+}
+
+// Выполнение команд на удаленном хосте через ssh (аргументы экранируются для удаленной оболочки)
+type sshRunner struct {
+	host        string
+	interactive bool
+}
+
+func (runner sshRunner) Command(name string, args ...string) *exec.Cmd {
+	remote := []string{shellQuote(name)}
+	for _, arg := range args {
+		remote = append(remote, shellQuote(arg))
+	}
+	return exec.Command("ssh", append(sshOptions(runner.interactive), "--", runner.host, strings.Join(remote, " "))...)
+}
+
+// Выполнение команд внутри контейнера или пода: команда передается без оболочки после префикса (docker exec <container>)
+type execRunner struct {
+	prefix []string
+}
+
+func (runner execRunner) Command(name string, args ...string) *exec.Cmd {
+	command := append(slices.Clone(runner.prefix[1:]), name)
+	return exec.Command(runner.prefix[0], append(command, args...)...)
+}
+
+// Функция для создания исполнителя команд по названию хоста:
+// пусто - локальный хост, docker://<container> и podman://<container> - контейнер,
+// kubectl://[namespace/]<pod>[/container] - под Kubernetes (для контейнера пода пространство имен обязательно),
+// остальные значения - удаленный хост ssh ([user@]server)
+func newCommandRunner(host string, interactive bool) CommandRunner {
+	tool, target, found := strings.Cut(host, "://")
+	switch {
+	case host == "":
+		return localRunner{}
+	case found && (tool == "docker" || tool == "podman"):
+		return execRunner{prefix: []string{tool, "exec", target}}
+	case found && tool == "kubectl":
+		parts := strings.Split(target, "/")
+		prefix := []string{"kubectl", "exec"}
+		if len(parts) > 1 {
+			prefix = append(prefix, "-n", parts[0])
+			parts = parts[1:]
+		}
+		prefix = append(prefix, parts[0])
+		if len(parts) > 1 {
+			prefix = append(prefix, "-c", parts[1])
+		}
+		return execRunner{prefix: append(prefix, "--")}
+	}
+	return sshRunner{host: host, interactive: interactive}
+}
+
+// Функция для создания команды на текущем хосте (локальный хост, если исполнитель не выбран)
+func (app *App) command(name string, args ...string) *exec.Cmd {
+	if app.runner == nil {
+		return exec.Command(name, args...)
+	}
+	return app.runner.Command(name, args...)
+}
+
+// Функция для подключения к удаленному хосту и определения его операционной системы
+func (app *App) connectHost(host string, interactive bool) error {
+	cmd := newCommandRunner(host, interactive).Command("uname", "-s")
+	if interactive {
+		cmd.Stdin = os.Stdin
+		cmd.Stderr = os.Stderr
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return err
+	}
+	if app.hostSystems == nil {
+		app.hostSystems = make(map[string]string)
+	}
+	app.hostSystems[host] = strings.ToLower(strings.TrimSpace(string(output)))
+	return nil
+}
+
+// Функция для выбора хоста, на котором выполняются команды (операционная система определяется при подключении)
+func (app *App) useHost(host string) {
+	app.host = host
+	app.runner = newCommandRunner(host, false)
+	app.getOS = runtime.GOOS
+	if system, ok := app.hostSystems[host]; ok && host != "" {
+		app.getOS = system
+	}
+}
+
+// Размер и дата изменения файла журнала
+type fileStat struct {
+	size    int64
+	modTime time.Time
+}
+
+// Максимальная длина путей файлов в одной команде stat на удаленном хосте (ограничение длины командной строки ARG_MAX)
+const maxStatArgsLength = 64 * 1024
+
+// Функция для получения размера и даты изменения файлов на текущем хосте (файлы без доступа пропускаются)
+// На удаленном хосте информация о файлах запрашивается командой stat для пакета путей общей длиной до maxStatArgsLength
+func (app *App) statFiles(paths []string) map[string]fileStat {
+	stats := make(map[string]fileStat)
+	if app.host == "" {
+		for _, path := range paths {
+			if info, err := os.Stat(path); err == nil {
+				stats[path] = fileStat{size: info.Size(), modTime: info.ModTime()}
+			}
+		}
+		return stats
+	}
+	paths = slices.DeleteFunc(slices.Clone(paths), func(path string) bool { return path == "" })
+	if len(paths) == 0 {
+		return stats
+	}
+	// Формат stat в GNU (Linux) и BSD (macOS/*BSD) отличается
+	format := []string{"-L", "-f", "%m %z %N"}
+	if app.getOS == "linux" {
+		format = []string{"-L", "-c", "%Y %s %n"}
+	}
+	for start := 0; start < len(paths); {
+		end, length := start, 0
+		for end < len(paths) && (end == start || length+len(paths[end]) < maxStatArgsLength) {
+			length += len(paths[end]) + 1
+			end++
+		}
+		// Ошибка возвращается, если хотя бы один файл недоступен, поэтому разбираем вывод в любом случае
+		output, _ := app.command("stat", append(append(format, "--"), paths[start:end]...)...).Output()
+		for _, line := range strings.Split(string(output), "\n") {
+			fields := strings.SplitN(line, " ", 3)
+			if len(fields) != 3 {
+				continue
+			}
+			seconds, errTime := strconv.ParseInt(fields[0], 10, 64)
+			size, errSize := strconv.ParseInt(fields[1], 10, 64)
+			if errTime == nil && errSize == nil {
+				stats[fields[2]] = fileStat{size: size, modTime: time.Unix(seconds, 0)}
+			}
+		}
+		start = end
+	}
+	return stats
+}
+
+// Функция для получения размера и даты изменения одного файла на текущем хосте
+func (app *App) statFile(path string) (fileStat, bool) {
+	stat, ok := app.statFiles([]string{path})[path]
+	return stat, ok
+}
+
+// Функция для переключения хоста списков журналов (открытые журналы продолжают обновляться со своих хостов)
+func (app *App) switchHost(host string) error {
+	if host != "" {
+		if err := app.connectHost(host, false); err != nil {
+			return err
+		}
+	}
+	app.useHost(host)
+	app.showHostTitle()
+	app.loadServices(app.selectUnits)
+	app.loadFiles(app.selectPath)
+	app.loadDockerContainer(app.selectContainerizationSystem)
+	return nil
+}
+
+// Функция для вывода текущего хоста в заголовке поля фильтрации списков
+func (app *App) showHostTitle() {
+	if app.noGui() {
+		return
+	}
+	v, err := app.gui.View("filterList")
+	if err != nil {
+		return
+	}
+	v.Title = "Filtering lists"
+	if app.host != "" {
+		v.Title += " [" + app.host + "]"
+	}
+}
+
+// Функция для получения списка хостов окна переключения (первым выводится локальный хост)
+func (app *App) hostList() []string {
+	return append([]string{""}, app.hosts...)
+}
+
+// Функция для вывода окна переключения хостов
+func (app *App) showHosts(g *gocui.Gui) {
+	hosts := app.hostList()
+	maxX, maxY := g.Size()
+	width := min(60, maxX-2)
+	height := min(len(hosts)+1, maxY-4)
+	x0 := (maxX - width) / 2
+	y0 := (maxY - height) / 2
+	hostsView, err := g.SetView("hosts", x0, y0, x0+width, y0+height, 0)
+	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+		return
+	}
+	hostsView.Title = " Hosts: Enter - switch "
+	hostsView.Highlight = true
+	hostsView.Wrap = false
+	hostsView.Autoscroll = false
+	hostsView.FrameColor = gocui.ColorGreen
+	hostsView.TitleColor = gocui.ColorGreen
+	hostsView.SelBgColor = gocui.ColorGreen
+	hostsView.SelFgColor = gocui.ColorBlack
+	hostsView.Clear()
+	for i, host := range hosts {
+		name := host
+		if host == "" {
+			name = "local"
+		}
+		if host == app.host {
+			fmt.Fprintln(hostsView, " ● "+name)
+			_ = hostsView.SetCursor(0, i)
+		} else {
+			fmt.Fprintln(hostsView, "   "+name)
+		}
+	}
+	if _, err := g.SetCurrentView("hosts"); err != nil {
+		return
+	}
+}
+
+// Функция для переключения на выбранный хост (ошибка подключения выводится в заголовке окна)
+func (app *App) selectHost(g *gocui.Gui, v *gocui.View) error {
+	_, originY := v.Origin()
+	_, cursorY := v.Cursor()
+	hosts := app.hostList()
+	index := originY + cursorY
+	if index >= len(hosts) {
+		return nil
+	}
+	if err := app.switchHost(hosts[index]); err != nil {
+		v.Title = " Hosts: " + err.Error() + " "
+		return nil
+	}
+	return app.closeHosts(g)
+}
+
+// Функция для закрытия окна переключения хостов
+func (app *App) closeHosts(g *gocui.Gui) error {
+	if err := g.DeleteView("hosts"); err != nil {
+		return err
+	}
+	return app.focusView(g, "filterList")
+}
+
+// ---------------------------------------- Mouse ----------------------------------------
+
+// Всплывающие окна, при открытии которых нажатия мыши вне окна игнорируются
+var popupViews = []string{"help", "patterns", "stats", "bookmarks", "note", "export", "goToLine", "diff", "alerts", "hosts"}
+
+// Окна, в которых курсор определяет выбранный элемент или позицию ввода
+// (gocui перемещает курсор под указатель при перемещении мыши, поэтому позиция курсора восстанавливается)
+var mouseCursorViews = []string{"filterList", "services", "varLogs", "docker", "filter", "bookmarks", "note", "goToLine", "patterns", "alerts", "hosts"}
+
+// Функция для сохранения позиций курсоров всех окон после обновления интерфейса
+// gocui перемещает курсор окна под указателем при любом событии мыши (в том числе при перемещении и прокрутке колесом),
+// поэтому позиция восстанавливается для событий, которые не выбирают элемент
+func (app *App) saveViewCursors(g *gocui.Gui) {
+	if app.viewCursors == nil {
+		app.viewCursors = make(map[string][2]int)
+	}
+	for _, v := range g.Views() {
+		x, y := v.Cursor()
+		app.viewCursors[v.Name()] = [2]int{x, y}
+	}
+}
+
+// Функция для восстановления позиции курсора окна после события мыши
+func (app *App) restoreViewCursor(v *gocui.View) {
+	if v == nil {
+		return
+	}
+	if cursor, ok := app.viewCursors[v.Name()]; ok {
+		_ = v.SetCursor(cursor[0], cursor[1])
+	}
+}
+
+// Функция для получения открытого всплывающего окна (nil, если окно не открыто)
+func (app *App) openPopup(g *gocui.Gui) *gocui.View {
+	for _, name := range popupViews {
+		if v, err := g.View(name); err == nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// Функция для переключения активного окна с выделением его рамки (аналогично переключению через Tab)
+func (app *App) focusView(g *gocui.Gui, name string) error {
+	frameColors := map[string]gocui.Attribute{
+		"filterList": gocui.ColorDefault,
+		"services":   app.journalListFrameColor,
+		"varLogs":    app.fileSystemFrameColor,
+		"docker":     app.dockerFrameColor,
+		"filter":     gocui.ColorDefault,
+		"timeline":   gocui.ColorDefault,
+		"logs":       gocui.ColorDefault,
+		"scrollLogs": gocui.ColorDefault,
+	}
+	for viewName, frameColor := range frameColors {
+		v, err := g.View(viewName)
+		if err != nil {
+			continue
+		}
+		if viewName == name || viewName == "scrollLogs" && name == "logs" {
+			v.FrameColor = gocui.ColorGreen
+			v.TitleColor = gocui.ColorGreen
+		} else {
+			v.FrameColor = frameColor
+			v.TitleColor = gocui.ColorDefault
+		}
+	}
+	if _, err := g.SetCurrentView(name); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Функция для получения индекса элемента списка, выбранного нажатием мыши (false, если нажатие ниже последнего элемента списка)
+// Курсор окна установлен gocui на строку под указателем, иначе нажатие было вне строк окна
+func clickedListItem(cursorY, row, start, count int) (int, bool) {
+	if row < 0 || cursorY != row || start+cursorY >= count {
+		return -1, false
+	}
+	return start + cursorY, true
+}
+
+// Функция для начала или завершения перетаскивания (пустое значение)
+// Во время перетаскивания перемещение мыши обрабатывается над любым окном, так как указатель выходит за пределы перетаскиваемого окна
+func (app *App) setMouseDrag(g *gocui.Gui, drag string) {
+	if app.mouseDrag != "" {
+		_ = g.DeleteKeybinding("", gocui.Key(0), gocui.ModNone)
+	}
+	app.mouseDrag = drag
+	if drag != "" {
+		_ = g.SetKeybinding("", gocui.Key(0), gocui.ModNone, app.mouseMove)
+	}
+}
+
+// Функция для получения строки окна под указателем мыши (-1, если указатель вне окна)
+func mouseRow(g *gocui.Gui, v *gocui.View) int {
+	_, y0, _, y1, err := g.ViewPosition(v.Name())
+	if err != nil {
+		return -1
+	}
+	_, mouseY := g.MousePosition()
+	if mouseY <= y0 || mouseY >= y1 {
+		return -1
+	}
+	return mouseY - y0 - 1
+}
+
+// Функция для прокрутки вывода журнала на позицию указателя мыши в полосе прокрутки
+func (app *App) scrollLogsToMouse(g *gocui.Gui) {
+	_, y0, _, y1, err := g.ViewPosition("scrollLogs")
+	if err != nil {
+		return
+	}
+	v, err := g.View("logs")
+	if err != nil {
+		return
+	}
+	_, viewHeight := v.Size()
+	maxPosition := len(app.filteredLogLines) - 1 - viewHeight
+	if maxPosition <= 0 {
+		return
+	}
+	// Позиция указателя ограничивается полосой прокрутки (указатель может выйти за ее пределы при перетаскивании)
+	_, mouseY := g.MousePosition()
+	scrollHeight := y1 - y0 - 1
+	row := max(0, min(mouseY-y0-1, scrollHeight-1))
+	app.logScrollPos = row * maxPosition / max(1, scrollHeight-1)
+	app.autoScroll = app.logScrollPos >= maxPosition
+	app.updateLogsView(false)
+}
+
+// Функция для обработки нажатия левой кнопки мыши: выбор окна, элемента списка или позиции в полосе прокрутки
+func (app *App) mouseClick(g *gocui.Gui, v *gocui.View) error {
+	app.setMouseDrag(g, "")
+	if v == nil || !v.Visible {
+		return nil
+	}
+	// Пока открыто всплывающее окно, нажатия вне его игнорируются
+	if popup := app.openPopup(g); popup != nil {
+		if v != popup {
+			app.restoreViewCursor(v)
+		}
+		return nil
+	}
+	switch v.Name() {
+	case "panelBorder":
+		app.setMouseDrag(g, "panelBorder")
+		return nil
+	case "services":
+		// Нажатие ниже последнего элемента списка только выбирает окно
+		_, cy := v.Cursor()
+		index, ok := clickedListItem(cy, mouseRow(g, v), app.startServices, len(app.journals))
+		if !ok {
+			app.restoreViewCursor(v)
+			return app.focusView(g, "services")
+		}
+		app.selectedJournal = index
+		if err := app.selectServiceByIndex(cy); err != nil {
+			return err
+		}
+		if err := app.focusView(g, "services"); err != nil {
+			return err
+		}
+		return app.selectService(g, v)
+	case "varLogs":
+		_, cy := v.Cursor()
+		index, ok := clickedListItem(cy, mouseRow(g, v), app.startFiles, len(app.logfiles))
+		if !ok {
+			app.restoreViewCursor(v)
+			return app.focusView(g, "varLogs")
+		}
+		app.selectedFile = index
+		if err := app.selectFileByIndex(cy); err != nil {
+			return err
+		}
+		if err := app.focusView(g, "varLogs"); err != nil {
+			return err
+		}
+		return app.selectFile(g, v)
+	case "docker":
+		_, cy := v.Cursor()
+		index, ok := clickedListItem(cy, mouseRow(g, v), app.startDockerContainers, len(app.dockerContainers))
+		if !ok {
+			app.restoreViewCursor(v)
+			return app.focusView(g, "docker")
+		}
+		app.selectedDockerContainer = index
+		if err := app.selectDockerByIndex(cy); err != nil {
+			return err
+		}
+		if err := app.focusView(g, "docker"); err != nil {
+			return err
+		}
+		return app.selectDocker(g, v)
+	case "scrollLogs":
+		app.setMouseDrag(g, "scrollLogs")
+		app.scrollLogsToMouse(g)
+		return app.focusView(g, "logs")
+	case "logs2", "scrollLogs2":
+		// Нажатие на второе окно делает его активным
+		return app.switchLogPane(g)
+	case "tabs":
+		return app.clickTab(g)
+	}
+	return app.focusView(g, v.Name())
+}
+
+// Функция для обработки перемещения мыши: восстановление курсора окна и перетаскивание границы левой колонки или полосы прокрутки
+func (app *App) mouseMove(g *gocui.Gui, v *gocui.View) error {
+	app.restoreViewCursor(v)
+	switch app.mouseDrag {
+	case "panelBorder":
+		mouseX, mouseY := g.MousePosition()
+		app.listsSize = mouseX
+		if app.layoutPreset == "rows" {
+			_, maxY := g.Size()
+			app.listsSize = maxY - mouseY
+		}
+	case "scrollLogs":
+		app.scrollLogsToMouse(g)
+	}
+	return nil
+}
+
+// Функция для завершения перетаскивания при отпускании кнопки мыши
+func (app *App) mouseRelease(g *gocui.Gui, v *gocui.View) error {
+	app.restoreViewCursor(v)
+	// Сохраняем размер панели списков после перетаскивания границы
+	if app.mouseDrag == "panelBorder" {
+		maxX, maxY := g.Size()
+		app.listsSize = app.listsPanelSize(maxX, maxY)
+		app.saveSessionFile()
+	}
+	app.setMouseDrag(g, "")
+	return nil
+}
+
+// Функция для прокрутки колесом мыши списка или вывода журнала под указателем (step: 1 - вниз, -1 - вверх)
+func (app *App) mouseWheel(step int) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if v == nil || !v.Visible {
+			return nil
+		}
+		app.restoreViewCursor(v)
+		if popup := app.openPopup(g); popup != nil && v != popup {
+			return nil
+		}
+		switch v.Name() {
+		case "services":
+			if step > 0 {
+				return app.nextService(v, 1)
+			}
+			return app.prevService(v, 1)
+		case "varLogs":
+			if step > 0 {
+				return app.nextFileName(v, 1)
+			}
+			return app.prevFileName(v, 1)
+		case "docker":
+			if step > 0 {
+				return app.nextDockerContainer(v, 1)
+			}
+			return app.prevDockerContainer(v, 1)
+		case "logs", "scrollLogs":
+			if step > 0 {
+				return app.scrollDownLogs(3)
+			}
+			return app.scrollUpLogs(3)
+		case "logs2", "scrollLogs2":
+			// Второе окно прокручивается без переключения активного окна
+			var err error
+			app.withSplitPane(func() {
+				if step > 0 {
+					err = app.scrollDownLogs(3)
+				} else {
+					err = app.scrollUpLogs(3)
+				}
+			})
+			return err
+		case "patterns":
+			return app.movePopupCursor(v, step, len(app.logPatterns))
+		case "bookmarks":
+			return app.movePopupCursor(v, step, len(app.bookmarkList))
+		case "diff":
+			return app.movePopupCursor(v, step, app.diffLines)
+		case "alerts":
+			return app.movePopupCursor(v, step, len(app.alertRules)+len(app.alertHits))
+		case "hosts":
+			return app.movePopupCursor(v, step, len(app.hostList()))
+		case "export":
+			return app.switchExportFormat(v, step)
+		}
+		return nil
+	}
+}
+
+// ---------------------------------------- Coloring ----------------------------------------
+
+// Функция для покраски строки
+func (app *App) lineColor(inputLine string) string {
+	// Проверяем, что строка в формате logfmt (key=value), для покраски ключей и уровня журнала из поля level
+	plainLine := inputLine
+	if strings.Contains(inputLine, "\x1b[") {
+		plainLine = removeANSI(inputLine)
+	}
+	// Строки журнала доступа веб-сервера красим с сохранением выравнивания колонок
+	if format, match := app.matchAccessLog(plainLine); format != nil {
+		return app.accessLogLineColor(inputLine, plainLine, format, match)
+	}
+	_, logfmtLine := parseLogfmtFields(plainLine)
+	// Разбиваем строку на слова
+	words := strings.Fields(inputLine)
+	var colorLine string
+	var filterColor bool = false
+	for _, word := range words {
+		// Исключаем строки с покраской при поиске (Background)
+		if strings.Contains(word, "\x1b[0;44m") {
+			filterColor = true
+		}
+		// Красим слово в функции
+		if !filterColor {
+			if logfmtLine {
+				word = app.logfmtWordColor(word)
+			} else {
+				word = app.wordColor(word)
+			}
+		}
+		// Возобновляем покраску
+		if strings.Contains(word, "\033[0m") {
+			filterColor = false
+		}
+		colorLine += word + " "
+	}
+	return strings.TrimSpace(colorLine)
+}
+
+// Функция для покраски строки журнала доступа
+// Код ответа красится по классу (2xx, 3xx, 4xx, 5xx), а пробелы между словами сохраняются для выравнивания колонок
+func (app *App) accessLogLineColor(inputLine string, plainLine string, format *accessLogFormat, match []int) string {
+	// Определяем номер слова, которое содержит код ответа
+	statusWord := -1
+	var status string
+	if index := format.regex.SubexpIndex("status"); index > 0 && match[2*index] >= 0 {
+		status = plainLine[match[2*index]:match[2*index+1]]
+		statusWord = len(strings.Fields(plainLine[:match[2*index]]))
+	}
+	var colorLine strings.Builder
+	var filterColor bool = false
+	wordIndex := 0
+	position := 0
+	for position < len(inputLine) {
+		start := position
+		for position < len(inputLine) && (inputLine[position] == ' ' || inputLine[position] == '\t') {
+			position++
+		}
+		colorLine.WriteString(inputLine[start:position])
+		if position >= len(inputLine) {
+			break
+		}
+		start = position
+		for position < len(inputLine) && inputLine[position] != ' ' && inputLine[position] != '\t' {
+			position++
+		}
+		word := inputLine[start:position]
+		// Исключаем строки с покраской при поиске (Background)
+		if strings.Contains(word, "\x1b[0;44m") {
+			filterColor = true
+		}
+		if !filterColor {
+			if color := statusColor(status); wordIndex == statusWord && color != "" {
+				word = strings.Replace(word, status, color+status+"\033[0m", 1)
+			} else {
+				word = app.wordColor(word)
+			}
+		}
+		// Возобновляем покраску
+		if strings.Contains(word, "\033[0m") {
+			filterColor = false
+		}
+		colorLine.WriteString(word)
+		wordIndex++
+	}
+	return colorLine.String()
+}
+
+// Функция для покраски пары key=value в строках формата logfmt
+// Ключи красятся в один цвет, а значение поля level по уровню журнала (вместо поиска ключевых слов)
+func (app *App) logfmtWordColor(inputWord string) string {
+	index := strings.IndexByte(inputWord, '=')
+	if index <= 0 || !isLogfmtKey(inputWord[:index]) {
+		return app.wordColor(inputWord)
+	}
+	key := inputWord[:index]
+	value := inputWord[index+1:]
+	coloredKey := "\033[36m" + key + "\033[0m" + "\033[35m=\033[0m"
+	if value == "" {
+		return coloredKey
+	}
+	if slices.Contains(jsonLevelKeys, strings.ToLower(key)) {
+		if color := levelColor(value); color != "" {
+			return coloredKey + color + value + "\033[0m"
+		}
+	}
+	// Выделяем открывающую кавычку и красим значение как обычное слово
+	if strings.HasPrefix(value, `"`) {
+		return coloredKey + "\033[35m\"\033[0m" + app.wordColor(value[1:])
+	}
+	return coloredKey + app.wordColor(value)
+}
+
+// Игнорируем регистр и проверяем, что слово окружено границами (не буквы и цифры)
+func (app *App) replaceWordLower(word, keyword, color string) string {
+	re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(keyword) + `\b`)
+	return re.ReplaceAllStringFunc(word, func(match string) string {
+		return color + match + "\033[0m"
+	})
+}
+
+// Поиск пользователей
+func (app *App) containsUser(searchWord string) bool {
+	for _, user := range app.userNameArray {
+		if user == searchWord {
+			return true
+		}
+	}
+	return false
+}
+
+// Поиск корневых директорий
+func (app *App) containsPath(searchWord string) bool {
+	for _, dir := range app.rootDirArray {
+		if strings.Contains(searchWord, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// Покраска url путей
+func (app *App) urlPathColor(cleanedWord string) string {
+	// Используем Builder для объединения строк
+	var sb strings.Builder
+	// Начинаем с желтого цвета
+	sb.WriteString("\033[33m")
+	for _, char := range cleanedWord {
+		switch {
+		// Пурпурный цвет для символов и возвращяем желтый
+		case char == '/' || char == '?' || char == '&' || char == '=' || char == ':' || char == '.':
+			sb.WriteString("\033[35m")
+			sb.WriteRune(char)
+			sb.WriteString("\033[33m")
+		// Синий цвет для цифр
+		// case unicode.IsDigit(char):
+		case char >= '0' && char <= '9':
+			sb.WriteString("\033[34m")
+			sb.WriteRune(char)
+			sb.WriteString("\033[33m")
+		default:
+			sb.WriteRune(char)
+		}
+	}
+	// Сброс цвета
+	sb.WriteString("\033[0m")
+	return sb.String()
+}
+
+// Функция для покраски словосочетаний
+func (app *App) wordColor(inputWord string) string {
+	// Опускаем регистр слова
+	inputWordLower := strings.ToLower(inputWord)
+	// Значение по умолчанию
+	var coloredWord string = inputWord
+	switch {
+	// URL
+	case strings.Contains(inputWord, "http://"):
+		cleanedWord := app.trimHttpRegex.ReplaceAllString(inputWord, "")
+		coloredChars := app.urlPathColor(cleanedWord)
+		// Красный для http
+		coloredWord = strings.ReplaceAll(inputWord, "http://"+cleanedWord, "\033[31mhttp\033[35m://"+coloredChars)
+	case strings.Contains(inputWord, "https://"):
+		cleanedWord := app.trimHttpsRegex.ReplaceAllString(inputWord, "")
+		coloredChars := app.urlPathColor(cleanedWord)
+		// Зеленый для https
+		coloredWord = strings.ReplaceAll(inputWord, "https://"+cleanedWord, "\033[32mhttps\033[35m://"+coloredChars)
+	// UNIX file paths
+	case app.containsPath(inputWord):
+		cleanedWord := app.trimPrefixPathRegex.ReplaceAllString(inputWord, "")
+		cleanedWord = app.trimPostfixPathRegex.ReplaceAllString(cleanedWord, "")
+		// Начинаем с желтого цвета
+		coloredChars := "\033[33m"
+		for _, char := range cleanedWord {
+			// Красим символы разделителя путей в пурпурный и возвращяем цвет
+			if char == '/' {
+				coloredChars += "\033[35m" + string(char) + "\033[33m"
+			} else {
+				coloredChars += string(char)
+			}
+		}
+		coloredWord = strings.ReplaceAll(inputWord, cleanedWord, "\033[35m"+coloredChars+"\033[0m")
+	// Желтый (известные имена: hostname и username) [33m]
+	case strings.Contains(inputWord, app.hostName):
+		coloredWord = strings.ReplaceAll(inputWord, app.hostName, "\033[33m"+app.hostName+"\033[0m")
+	case strings.Contains(inputWord, app.userName):
+		coloredWord = strings.ReplaceAll(inputWord, app.userName, "\033[33m"+app.userName+"\033[0m")
+	// Список пользователей из passwd
+	case app.containsUser(inputWord):
+		coloredWord = app.replaceWordLower(inputWord, inputWord, "\033[33m")
+	case strings.Contains(inputWordLower, "warn"):
+		words := []string{"warnings", "warning", "warn"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[33m")
+				break
+			}
+		}
+	// UNIX processes
+	case app.syslogUnitRegex.MatchString(inputWord):
+		unitSplit := strings.Split(inputWord, "[")
+		unitName := unitSplit[0]
+		unitId := strings.ReplaceAll(unitSplit[1], "]:", "")
+		coloredWord = strings.ReplaceAll(inputWord, inputWord, "\033[36m"+unitName+"\033[0m"+"\033[33m"+"["+"\033[0m"+"\033[34m"+unitId+"\033[0m"+"\033[33m"+"]"+"\033[0m"+":")
+	case strings.HasPrefix(inputWordLower, "kernel:"):
+		coloredWord = app.replaceWordLower(inputWord, "kernel", "\033[36m")
+	case strings.HasPrefix(inputWordLower, "rsyslogd:"):
+		coloredWord = app.replaceWordLower(inputWord, "rsyslogd", "\033[36m")
+	case strings.HasPrefix(inputWordLower, "sudo:"):
+		coloredWord = app.replaceWordLower(inputWord, "sudo", "\033[36m")
+	// Исключения
+	case strings.Contains(inputWordLower, "unblock"):
+		words := []string{"unblocking", "unblocked", "unblock"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	// Красный (ошибки) [31m]
+	case strings.Contains(inputWordLower, "err"):
+		words := []string{"stderr", "errors", "error", "erro", "err"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "dis"):
+		words := []string{"disconnected", "disconnection", "disconnects", "disconnect", "disabled", "disabling", "disable"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "crash"):
+		words := []string{"crashed", "crashing", "crash"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "delet"):
+		words := []string{"deletion", "deleted", "deleting", "deletes", "delete"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "remov"):
+		words := []string{"removing", "removed", "removes", "remove"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "stop"):
+		words := []string{"stopping", "stopped", "stoped", "stops", "stop"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "invalid"):
+		words := []string{"invalidation", "invalidating", "invalidated", "invalidate", "invalid"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "abort"):
+		words := []string{"aborted", "aborting", "abort"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "block"):
+		words := []string{"blocked", "blocker", "blocking", "blocks", "block"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "activ"):
+		words := []string{"inactive", "deactivated", "deactivating", "deactivate"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "exit"):
+		words := []string{"exited", "exiting", "exits", "exit"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "crit"):
+		words := []string{"critical", "critic", "crit"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "fail"):
+		words := []string{"failed", "failure", "failing", "fails", "fail"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "reject"):
+		words := []string{"rejecting", "rejection", "rejected", "reject"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "fatal"):
+		words := []string{"fatality", "fataling", "fatals", "fatal"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "clos"):
+		words := []string{"closed", "closing", "close"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "drop"):
+		words := []string{"dropped", "droping", "drops", "drop"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "kill"):
+		words := []string{"killer", "killing", "kills", "kill"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "cancel"):
+		words := []string{"cancellation", "cancelation", "canceled", "cancelling", "canceling", "cancel"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "refus"):
+		words := []string{"refusing", "refused", "refuses", "refuse"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "restrict"):
+		words := []string{"restricting", "restricted", "restriction", "restrict"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "panic"):
+		words := []string{"panicked", "panics", "panic"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[31m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "unknown"):
+		coloredWord = app.replaceWordLower(inputWord, "unknown", "\033[31m")
+	case strings.Contains(inputWordLower, "unavailable"):
+		coloredWord = app.replaceWordLower(inputWord, "unavailable", "\033[31m")
+	case strings.Contains(inputWordLower, "unsuccessful"):
+		coloredWord = app.replaceWordLower(inputWord, "unsuccessful", "\033[31m")
+	case strings.Contains(inputWordLower, "found"):
+		coloredWord = app.replaceWordLower(inputWord, "found", "\033[31m")
+	case strings.Contains(inputWordLower, "denied"):
+		coloredWord = app.replaceWordLower(inputWord, "denied", "\033[31m")
+	case strings.Contains(inputWordLower, "conflict"):
+		coloredWord = app.replaceWordLower(inputWord, "conflict", "\033[31m")
+	case strings.Contains(inputWordLower, "false"):
+		coloredWord = app.replaceWordLower(inputWord, "false", "\033[31m")
+	case strings.Contains(inputWordLower, "none"):
+		coloredWord = app.replaceWordLower(inputWord, "none", "\033[31m")
+	case strings.Contains(inputWordLower, "null"):
+		coloredWord = app.replaceWordLower(inputWord, "null", "\033[31m")
+	// Исключения
+	case strings.Contains(inputWordLower, "res"):
+		words := []string{"resolved", "resolving", "resolve", "restarting", "restarted", "restart"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	// Зеленый (успех) [32m]
+	case strings.Contains(inputWordLower, "succe"):
+		words := []string{"successfully", "successful", "succeeded", "succeed", "success"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "complet"):
+		words := []string{"completed", "completing", "completion", "completes", "complete"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "accept"):
+		words := []string{"accepted", "accepting", "acception", "acceptance", "acceptable", "acceptably", "accepte", "accepts", "accept"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "connect"):
+		words := []string{"connected", "connecting", "connection", "connects", "connect"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "finish"):
+		words := []string{"finished", "finishing", "finish"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "start"):
+		words := []string{"started", "starting", "startup", "start"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "creat"):
+		words := []string{"created", "creating", "creates", "create"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "enable"):
+		words := []string{"enabled", "enables", "enable"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "allow"):
+		words := []string{"allowed", "allowing", "allow"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "post"):
+		words := []string{"posting", "posted", "postrouting", "post"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "rout"):
+		words := []string{"prerouting", "routing", "routes", "route"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "forward"):
+		words := []string{"forwarding", "forwards", "forward"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "pass"):
+		words := []string{"passed", "passing", "password"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "run"):
+		words := []string{"running", "runs", "run"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "add"):
+		words := []string{"added", "add"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "open"):
+		words := []string{"opening", "opened", "open"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[32m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "ok"):
+		coloredWord = app.replaceWordLower(inputWord, "ok", "\033[32m")
+	case strings.Contains(inputWordLower, "available"):
+		coloredWord = app.replaceWordLower(inputWord, "available", "\033[32m")
+	case strings.Contains(inputWordLower, "accessible"):
+		coloredWord = app.replaceWordLower(inputWord, "accessible", "\033[32m")
+	case strings.Contains(inputWordLower, "done"):
+		coloredWord = app.replaceWordLower(inputWord, "done", "\033[32m")
+	case strings.Contains(inputWordLower, "true"):
+		coloredWord = app.replaceWordLower(inputWord, "true", "\033[32m")
+	// Синий (статусы) [36m]
+	case strings.Contains(inputWordLower, "req"):
+		words := []string{"requested", "requests", "request"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "reg"):
+		words := []string{"registered", "registeration"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "boot"):
+		words := []string{"reboot", "booting", "boot"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "out"):
+		words := []string{"stdout", "timeout", "output"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "put"):
+		words := []string{"input", "put"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "get"):
+		words := []string{"getting", "get"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "set"):
+		words := []string{"settings", "setting", "setup", "set"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "head"):
+		words := []string{"headers", "header", "heades", "head"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "log"):
+		words := []string{"logged", "login"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "load"):
+		words := []string{"overloading", "overloaded", "overload", "uploading", "uploaded", "uploads", "upload", "downloading", "downloaded", "downloads", "download", "loading", "loaded", "load"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "read"):
+		words := []string{"reading", "readed", "read"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "patch"):
+		words := []string{"patching", "patched", "patch"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "up"):
+		words := []string{"updates", "updated", "updating", "update", "upgrades", "upgraded", "upgrading", "upgrade", "backup", "up"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "listen"):
+		words := []string{"listening", "listener", "listen"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "launch"):
+		words := []string{"launched", "launching", "launch"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "chang"):
+		words := []string{"changed", "changing", "change"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "clea"):
+		words := []string{"cleaning", "cleaner", "clearing", "cleared", "clear"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "skip"):
+		words := []string{"skipping", "skipped", "skip"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "miss"):
+		words := []string{"missing", "missed"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "mount"):
+		words := []string{"mountpoint", "mounted", "mounting", "mount"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "auth"):
+		words := []string{"authenticating", "authentication", "authorization"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "conf"):
+		words := []string{"configurations", "configuration", "configuring", "configured", "configure", "config", "conf"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "option"):
+		words := []string{"options", "option"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "writ"):
+		words := []string{"writing", "writed", "write"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "sav"):
+		words := []string{"saved", "saving", "save"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "paus"):
+		words := []string{"paused", "pausing", "pause"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "filt"):
+		words := []string{"filtration", "filtr", "filtering", "filtered", "filter"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "norm"):
+		words := []string{"normal", "norm"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "noti"):
+		words := []string{"notifications", "notification", "notify", "noting", "notice"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "alert"):
+		words := []string{"alerting", "alert"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "in"):
+		words := []string{"informations", "information", "informing", "informed", "info", "installation", "installed", "installing", "install", "initialization", "initial", "using"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "down"):
+		words := []string{"shutdown", "down"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "us"):
+		words := []string{"status", "used", "use"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[36m")
+				break
+			}
+		}
+	case strings.Contains(inputWordLower, "debug"):
+		coloredWord = app.replaceWordLower(inputWord, "debug", "\033[36m")
+	case strings.Contains(inputWordLower, "verbose"):
+		coloredWord = app.replaceWordLower(inputWord, "verbose", "\033[36m")
+	case strings.HasPrefix(inputWordLower, "trace"):
+		coloredWord = app.replaceWordLower(inputWord, "trace", "\033[36m")
+	case strings.HasPrefix(inputWordLower, "protocol"):
+		coloredWord = app.replaceWordLower(inputWord, "protocol", "\033[36m")
+	case strings.Contains(inputWordLower, "level"):
+		coloredWord = app.replaceWordLower(inputWord, "level", "\033[36m")
+	// Голубой (цифры) [34m]
+	// Byte (0x04)
+	case app.hexByteRegex.MatchString(inputWord):
+		coloredWord = app.hexByteRegex.ReplaceAllStringFunc(inputWord, func(match string) string {
+			colored := ""
+			for _, char := range match {
+				if char == 'x' {
+					colored += "\033[35m" + string(char) + "\033[0m"
+				} else {
+					colored += "\033[34m" + string(char) + "\033[0m"
+				}
+			}
+			return colored
+		})
+	// DateTime
+	case app.dateTimeRegex.MatchString(inputWord):
+		coloredWord = app.dateTimeRegex.ReplaceAllStringFunc(inputWord, func(match string) string {
+			colored := ""
+			for _, char := range match {
+				if char == '-' || char == '.' || char == ':' || char == '+' || char == 'T' {
+					// Пурпурный для символов
+					colored += "\033[35m" + string(char) + "\033[0m"
+				} else {
+					// Синий для цифр
+					colored += "\033[34m" + string(char) + "\033[0m"
+				}
+			}
+			return colored
+		})
+	// Time + MAC
+	case app.timeMacAddressRegex.MatchString(inputWord):
+		coloredWord = app.timeMacAddressRegex.ReplaceAllStringFunc(inputWord, func(match string) string {
+			colored := ""
+			for _, char := range match {
+				if char == '-' || char == ':' || char == '.' || char == ',' || char == '+' {
+					colored += "\033[35m" + string(char) + "\033[0m"
+				} else {
+					colored += "\033[34m" + string(char) + "\033[0m"
+				}
+			}
+			return colored
+		})
+	// Date + IP
+	case app.dateIpAddressRegex.MatchString(inputWord):
+		coloredWord = app.dateIpAddressRegex.ReplaceAllStringFunc(inputWord, func(match string) string {
+			colored := ""
+			for _, char := range match {
+				if char == '.' || char == ':' || char == '-' || char == '+' {
+					colored += "\033[35m" + string(char) + "\033[0m"
+				} else {
+					colored += "\033[34m" + string(char) + "\033[0m"
+				}
+			}
+			return colored
+		})
+	// Percentage (100%)
+	case strings.Contains(inputWordLower, "%"):
+		coloredWord = app.procRegex.ReplaceAllStringFunc(inputWord, func(match string) string {
+			colored := ""
+			for _, char := range match {
+				if char == '%' {
+					colored += "\033[35m" + string(char) + "\033[0m"
+				} else {
+					colored += "\033[34m" + string(char) + "\033[0m"
+				}
+			}
+			return colored
+		})
+	// tcpdump
+	case strings.Contains(inputWordLower, "tcp"):
+		coloredWord = app.replaceWordLower(inputWord, "tcp", "\033[33m")
+	case strings.Contains(inputWordLower, "udp"):
+		coloredWord = app.replaceWordLower(inputWord, "udp", "\033[33m")
+	case strings.Contains(inputWordLower, "icmp"):
+		coloredWord = app.replaceWordLower(inputWord, "icmp", "\033[33m")
+	case strings.Contains(inputWordLower, "ip"):
+		words := []string{"ip4", "ipv4", "ip6", "ipv6", "ip"}
+		for _, word := range words {
+			if strings.Contains(inputWordLower, word) {
+				coloredWord = app.replaceWordLower(inputWord, word, "\033[33m")
+				break
+			}
+		}
+	// Update delimiter
+	case strings.Contains(inputWord, "⎯"):
+		coloredWord = app.replaceWordLower(inputWord, "⎯", "\033[32m")
+	// Исключения
+	case strings.Contains(inputWordLower, "not"):
+		coloredWord = app.replaceWordLower(inputWord, "not", "\033[31m")
+	}
+	return coloredWord
+}
+
+// ---------------------------------------- Log output ----------------------------------------
+
+// Функция для обновления вывода журнала (параметр для прокрутки в самый вниз)
+func (app *App) updateLogsView(lowerDown bool) {
+	// Получаем доступ к выводу журнала
+	v, err := app.gui.View(app.logsViewName())
+	if err != nil {
+		return
+	}
+	// Очищаем окно для отображения новых строк
+	v.Clear()
+	// Длинные строки переносятся, если не включен режим горизонтальной прокрутки
+	v.Wrap = !app.noWrapMode
+	// Получаем ширину и высоту окна
+	viewWidth, viewHeight := v.Size()
+	// Опускаем в самый низ, только если это не ручной скролл (отключается параметром)
+	if lowerDown {
+		// Если количество строк больше высоты окна, опускаем в самый низ
+		if len(app.filteredLogLines) > viewHeight-1 {
+			app.logScrollPos = len(app.filteredLogLines) - viewHeight - 1
+		} else {
+			app.logScrollPos = 0
+		}
+	}
+	// Определяем количество строк для отображения, начиная с позиции logScrollPos
+	startLine := app.logScrollPos
+	endLine := startLine + viewHeight
+	if endLine > len(app.filteredLogLines) {
+		endLine = len(app.filteredLogLines)
+	}
+	// Учитываем auto wrap (только в конце лога и только в режиме переноса строк)
+	if !app.noWrapMode && app.logScrollPos == len(app.filteredLogLines)-viewHeight-1 {
+		var viewLines int = 0                             // количество строк для вывода
+		var viewCounter int = 0                           // обратный счетчик видимых строк для остановки
+		var viewIndex int = len(app.filteredLogLines) - 1 // начальный индекс для строк с конца
+		for {
+			// Фиксируем текущую входную строку и счетчик
+			viewLines += 1
+			viewCounter += 1
+			// Получаем длинну видимых символов в строке с конца
+			var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+			lengthLine := len([]rune(ansiEscape.ReplaceAllString(app.filteredLogLines[viewIndex], "")))
+			// Если длинна строки больше ширины окна, получаем количество дополнительных строк
+			if lengthLine > viewWidth {
+				// Увеличивая счетчик и пропускаем строки
+				viewCounter += lengthLine / viewWidth
+			}
+			// Если счетчик привысил количество видимых строк, вычетаем последнюю строку из видимости
+			if viewCounter > viewHeight {
+				viewLines -= 1
+			}
+			if viewCounter >= viewHeight {
+				break
+			}
+			// Уменьшаем индекс
+			viewIndex -= 1
+		}
+		for i := len(app.filteredLogLines) - viewLines - 1; i < endLine; i++ {
+			fmt.Fprintln(v, app.selectedLineView(i))
+		}
+	} else {
+		// Проходим по отфильтрованным строкам и выводим их
+		for i := startLine; i < endLine; i++ {
+			fmt.Fprintln(v, app.selectedLineView(i))
+		}
+	}
+	// Сдвигаем видимую часть строк на позицию горизонтальной прокрутки (сбрасывается при очистке окна)
+	if app.noWrapMode && app.logScrollX > 0 {
+		_ = v.SetOrigin(app.logScrollX, 0)
+	}
+	// Вычисляем процент прокрутки и обновляем заголовок
+	var percentage int = 0
+	if len(app.filteredLogLines) > 0 {
+		// Стартовая позиция + размер текущего вывода логов и округляем в большую сторону (math)
+		percentage = int(math.Ceil(float64((startLine+viewHeight)*100) / float64(len(app.filteredLogLines))))
+		if percentage > 100 {
+			v.Title = fmt.Sprintf("Logs: 100%% (%d) ["+app.debugLoadTime+"]", len(app.filteredLogLines)) // "Logs: 100%% (%d) [Max lines: "+app.logViewCount+"/Load time: "+app.debugLoadTime+"]"
+		} else {
+			v.Title = fmt.Sprintf("Logs: %d%% (%d/%d) ["+app.debugLoadTime+"]", percentage, startLine+1+viewHeight, len(app.filteredLogLines))
+		}
+	} else {
+		v.Title = "Logs: 0% (0) [" + app.debugLoadTime + "]"
+	}
+	if app.patternFilter != nil {
+		v.Title += " [Pattern]"
+	}
+	if app.selectMode {
+		start, end := app.selectedRange()
+		v.Title += fmt.Sprintf(" [Select: %d]", end-start+1)
+	}
+	if app.noWrapMode {
+		if app.logScrollX > 0 {
+			v.Title += fmt.Sprintf(" [No wrap: column %d]", app.logScrollX+1)
+		} else {
+			v.Title += " [No wrap]"
+		}
+	}
+	// В режиме сравнения выводим источник каждого окна
+	if app.splitPane != nil {
+		if app.lastSelected != "" {
+			v.Title += " - " + removeANSI(app.lastSelected)
+		}
+		if app.syncScroll && !app.background {
+			v.Title += " [Sync]"
+		}
+	}
+	if app.lastHost != "" {
+		v.Title += " [" + app.lastHost + "]"
+	}
+	if app.pinned {
+		v.Title += " [Pinned]"
+	}
+	if app.previousInstance {
+		v.Title += " [Previous]"
+	}
+	if app.alertsUnseen > 0 && !app.background {
+		v.Title += fmt.Sprintf(" [Alerts: %d]", app.alertsUnseen)
+	}
+	if app.sessionError != "" && !app.background {
+		v.Title += " [Session file " + app.sessionError + "]"
+	}
+	app.viewScrollLogs(percentage)
+	if app.background || app.hidden {
+		return
+	}
+	app.drawTimeline()
+	app.drawTabs()
+	if app.syncScroll {
+		app.syncSplitPane()
+	}
+}
+
+// Функция для обновления интерфейса скроллинга
+func (app *App) viewScrollLogs(percentage int) {
+	vScroll, _ := app.gui.View(app.scrollViewName())
+	vScroll.Clear()
+	// Определяем высоту окна
+	_, viewHeight := vScroll.Size()
+	// Заполняем скролл пробелами, если вывод пустой или не выходит за пределы окна
+	if percentage == 0 || percentage > 100 {
+		fmt.Fprintln(vScroll, "▲")
+		for i := 1; i < viewHeight-1; i++ {
+			fmt.Fprintln(vScroll, " ")
+		}
+		fmt.Fprintln(vScroll, "▼")
+	} else {
+		// Рассчитываем позицию курсора (корректируем процент на размер скролла и верхней стрелки)
+		scrollPosition := (viewHeight*percentage)/100 - 3 - 1
+		fmt.Fprintln(vScroll, "▲")
+		// Выводим строки с пробелами и символом █
+	for_scroll:
+		for i := 1; i < viewHeight-3; i++ {
+			// Проверяем текущую поизицию
+			switch {
+			case i == scrollPosition:
+				// Выводим скролл
+				fmt.Fprintln(vScroll, "███")
+			case scrollPosition <= 0 || app.logScrollPos == 0:
+				// Если вышли за пределы окна или текст находится в самом начале, устанавливаем курсор в начало
+				fmt.Fprintln(vScroll, "███")
+				// Остальное заполняем пробелами с учетом стрелки и курсора (-4) до последней стрелки (-1)
+				for i := 4; i < viewHeight-1; i++ {
+					fmt.Fprintln(vScroll, " ")
+				}
+				break for_scroll
+			default:
+				// Пробелы на остальных строках
+				fmt.Fprintln(vScroll, " ")
+			}
+		}
+		fmt.Fprintln(vScroll, "▼")
+	}
+}
+
+// Функция для получения максимальной ширины видимых строк вывода журнала (без escape-последовательностей)
+func (app *App) visibleLinesWidth(viewHeight int) int {
+	width := 0
+	for i := app.logScrollPos; i < app.logScrollPos+viewHeight && i < len(app.filteredLogLines); i++ {
+		width = max(width, utf8.RuneCountInString(removeANSI(app.filteredLogLines[i])))
+	}
+	return width
+}
+
+// Функция для включения/отключения переноса длинных строк (позиция горизонтальной прокрутки сбрасывается)
+func (app *App) toggleWrapMode() {
+	app.noWrapMode = !app.noWrapMode
+	app.logScrollX = 0
+	app.updateLogsView(false)
+}
+
+// Функция для горизонтального скроллинга в режиме без переноса строк (шаг - четверть ширины окна)
+// Прокрутка ограничена самой длинной видимой строкой
+func (app *App) scrollHorizontalLogs(direction int) error {
+	if !app.noWrapMode {
+		return nil
+	}
+	v, err := app.gui.View(app.logsViewName())
+	if err != nil {
+		return err
+	}
+	viewWidth, viewHeight := v.Size()
+	step := max(1, viewWidth/4)
+	maxScroll := max(0, app.visibleLinesWidth(viewHeight)-viewWidth)
+	app.logScrollX = max(0, min(app.logScrollX+direction*step, maxScroll))
+	app.updateLogsView(false)
+	return nil
+}
+
+// Функция для скроллинга вниз
+func (app *App) scrollDownLogs(step int) error {
+	if app.selectMode {
+		return app.moveSelection(step)
+	}
+	v, err := app.gui.View(app.logsViewName())
+	if err != nil {
+		return err
+	}
+	// Получаем высоту окна, что бы не опускать лог с пустыми строками
+	_, viewHeight := v.Size()
+	// Проверяем, что размер журнала больше размера окна
+	if len(app.filteredLogLines) > viewHeight {
+		// Увеличиваем позицию прокрутки
+		app.logScrollPos += step
+		// Если достигнут конец списка, останавливаем на максимальной длинне с учетом высоты окна
+		if app.logScrollPos > len(app.filteredLogLines)-1-viewHeight {
+			app.logScrollPos = len(app.filteredLogLines) - 1 - viewHeight
+			// Включаем автоскролл
+			app.autoScroll = true
+		}
+		// Вызываем функцию для обновления отображения журнала
+		app.updateLogsView(false)
+	}
+	return nil
+}
+
+// Функция для скроллинга вверх
+func (app *App) scrollUpLogs(step int) error {
+	if app.selectMode {
+		return app.moveSelection(-step)
+	}
+	app.logScrollPos -= step
+	if app.logScrollPos < 0 {
+		app.logScrollPos = 0
+	}
+	// Отключаем автоскролл
+	app.autoScroll = false
+	app.updateLogsView(false)
+	return nil
+}
+
+// Функция для переход к началу журнала
+func (app *App) pageUpLogs() {
+	app.logScrollPos = 0
+	app.autoScroll = false
+	app.updateLogsView(false)
+}
+
+// Функция для повторной фильтрации текущего журнала без изменения позиции прокрутки (например, после сворачивания записей)
+func (app *App) refreshFilter() {
+	// Сбрасываем последний текст фильтра, что бы фильтрация не была пропущена при ручном скролле
+	app.lastFilterText = app.filterText + "\x00"
+	app.applyFilter(false)
+}
+
+// Функция для очистки поля ввода фильтра
+func (app *App) clearFilterEditor(g *gocui.Gui) {
+	v, _ := g.View("filter")
+	// Очищаем содержимое View
+	v.Clear()
+	// Устанавливаем курсор на начальную позицию
+	if err := v.SetCursor(0, 0); err != nil {
+		return
+	}
+	// Очищаем буфер фильтра и выбранный шаблон
+	app.filterText = ""
+	app.patternFilter = nil
+	app.applyFilter(false)
+}
+
+// Функция для обновления последнего выбранного вывода лога
+func (app *App) updateLogOutput(seconds int) {
+	for {
+		// Выполняем обновление интерфейса через метод Update для иницилизации перерисовки интерфейса
+		app.gui.Update(func(g *gocui.Gui) error {
+			// Сбрасываем автоскролл, если это ручное обновление, что бы опустить журнал вниз
+			if seconds == 0 {
+				app.autoScroll = true
+			}
+			previous, unseen := app.currentLogLines, app.alertsUnseen
+			app.reloadLogPane()
+			app.checkAlerts(previous)
+			// Второе окно режима сравнения и неактивные вкладки обновляются вместе с активным
+			// (новые строки проверяются по правилам оповещений, если окно закреплено)
+			app.withSplitPane(func() {
+				previous := app.currentLogLines
+				app.reloadLogPane()
+				if app.pinned {
+					app.checkAlerts(previous)
+				}
+			})
+			app.reloadTabs()
+			// Выводим количество новых срабатываний в заголовке активного окна
+			if app.alertsUnseen != unseen {
+				app.updateLogsView(false)
+			}
+			return nil
+		})
+		if seconds == 0 {
+			break
+		}
+		time.Sleep(time.Duration(seconds) * time.Second)
+	}
+}
+
+// Функция для повторной загрузки последнего выбранного журнала окна вывода
+// Журнал загружается с того хоста, с которого он был открыт, независимо от текущего хоста списков
+func (app *App) reloadLogPane() {
+	if app.lastHost != app.host {
+		host := app.host
+		app.useHost(app.lastHost)
+		defer app.useHost(host)
+	}
+	switch app.lastWindow {
+	case "services":
+		app.loadJournalLogs(app.lastSelected, false)
+	case "varLogs":
+		app.loadFileLogs(app.lastSelected, false)
+	case "docker":
+		app.loadDockerLogs(app.lastSelected, false)
+	}
+}
+
+// Функция для обновления вывода при изменение размера окна
+func (app *App) updateWindowSize(seconds int) {
+	for {
+		app.gui.Update(func(g *gocui.Gui) error {
+			v, err := g.View("logs")
+			if err != nil {
+				log.Panicln(err)
+			}
+			windowWidth, windowHeight := v.Size()
+			if windowWidth != app.windowWidth || windowHeight != app.windowHeight {
+				app.windowWidth, app.windowHeight = windowWidth, windowHeight
+				app.withSplitPane(func() {
+					app.updateLogsView(app.autoScroll)
+				})
+				app.updateLogsView(true)
+				if v, err := g.View("services"); err == nil {
+					_, viewHeight := v.Size()
+					app.maxVisibleServices = viewHeight
+				}
+				if v, err := g.View("varLogs"); err == nil {
+					_, viewHeight := v.Size()
+					app.maxVisibleFiles = viewHeight
+				}
+				if v, err := g.View("docker"); err == nil {
+					_, viewHeight := v.Size()
+					app.maxVisibleDockerContainers = viewHeight
+				}
+				app.applyFilterList()
+			}
+			return nil
+		})
+		time.Sleep(time.Duration(seconds) * time.Second)
+	}
+}
+
+// Функция для фиксации места загрузки журнала с помощью делиметра
+func (app *App) updateDelimiter(newUpdate bool) {
+	// Фиксируем текущую длинну массива (индекс) для вставки строки обновления, если это ручной выбор из списка
+	if newUpdate {
+		app.newUpdateIndex = len(app.currentLogLines) - 1
+		// Сбрасываем автоскролл
+		app.autoScroll = true
+		// Фиксируем время загрузки журнала
+		app.updateTime = time.Now().Format("15:04:05")
+	}
+	// Проверяем, что массив не пустой и уже привысил длинну новых сообщений
+	if app.newUpdateIndex > 0 && len(app.currentLogLines)-1 > app.newUpdateIndex {
+		// Формируем длинну делимитра
+		v, _ := app.gui.View(app.logsViewName())
+		width, _ := v.Size()
+		lengthDelimiter := width/2 - 5
+		delimiter1 := strings.Repeat("⎯", lengthDelimiter)
+		delimiter2 := delimiter1
+		if width > lengthDelimiter+lengthDelimiter+10 {
+			delimiter2 = strings.Repeat("⎯", lengthDelimiter+1)
+		}
+		var delimiterString string = delimiter1 + " " + app.updateTime + " " + delimiter2
+		// Вставляем новую строку после указанного индекса, сдвигая остальные строки массива
+		app.currentLogLines = append(app.currentLogLines[:app.newUpdateIndex],
+			append([]string{delimiterString}, app.currentLogLines[app.newUpdateIndex:]...)...)
+	}
+}
+
+// ---------------------------------------- Key Binding ----------------------------------------
+
+// Функция для биндинга клавиш
+func (app *App) setupKeybindings() error {
+	// Ctrl+C для выхода из приложения
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, quit); err != nil {
+		return err
+	}
+	// Tab для переключения между окнами
+	if err := app.gui.SetKeybinding("", gocui.KeyTab, gocui.ModNone, app.nextView); err != nil {
+		return err
+	}
+	// Shift+Tab для переключения между окнами в обратном порядке
+	if err := app.gui.SetKeybinding("", gocui.KeyBacktab, gocui.ModNone, app.backView); err != nil {
+		return err
+	}
+	// Enter для выбора службы и загрузки журналов
+	if err := app.gui.SetKeybinding("services", gocui.KeyEnter, gocui.ModNone, app.selectService); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyEnter, gocui.ModNone, app.selectFile); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyEnter, gocui.ModNone, app.selectDocker); err != nil {
+		return err
+	}
+	// Возврат к предыдущему уровню списка Kubernetes (Backspace)
+	for _, key := range []gocui.Key{gocui.KeyBackspace, gocui.KeyBackspace2} {
+		if err := app.gui.SetKeybinding("docker", key, gocui.ModNone, app.kubeLevelUp); err != nil {
+			return err
+		}
+	}
+	// Журнал предыдущего экземпляра контейнера пода (P)
+	for _, view := range []string{"docker", "logs"} {
+		if err := app.gui.SetKeybinding(view, 'P', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			app.togglePreviousInstance()
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	// Список файлов журналов внутри выбранного контейнера (f)
+	if err := app.gui.SetKeybinding("docker", 'f', gocui.ModNone, app.browseContainerFiles); err != nil {
+		return err
+	}
+	// Перемещение вниз к следующей службе (функция nextService), файлу (nextFileName) или контейнеру (nextDockerContainer)
+	if err := app.gui.SetKeybinding("services", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextService(v, 1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextFileName(v, 1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextDockerContainer(v, 1)
+	}); err != nil {
+		return err
+	}
+	// Быстрое пролистывание вниз через 10 записей (Shift+Down)
+	if err := app.gui.SetKeybinding("services", gocui.KeyArrowDown, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextService(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowDown, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextFileName(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyArrowDown, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextDockerContainer(v, 10)
+	}); err != nil {
+		return err
+	}
+	// Alt+Down 100
+	if err := app.gui.SetKeybinding("services", gocui.KeyArrowDown, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextService(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowDown, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextFileName(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyArrowDown, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextDockerContainer(v, 100)
+	}); err != nil {
+		return err
+	}
+	// Shift+D на 10 для macOS
+	if err := app.gui.SetKeybinding("services", 'D', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextService(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", 'D', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextFileName(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", 'D', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextDockerContainer(v, 10)
+	}); err != nil {
+		return err
+	}
+	// Ctrl+D на 100 для macOS
+	if err := app.gui.SetKeybinding("services", gocui.KeyCtrlD, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextService(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyCtrlD, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextFileName(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyCtrlD, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextDockerContainer(v, 100)
+	}); err != nil {
+		return err
+	}
+	// Pgdn 1
+	if err := app.gui.SetKeybinding("services", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextService(v, 1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextFileName(v, 1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextDockerContainer(v, 1)
+	}); err != nil {
+		return err
+	}
+	// Shift+Pgdn 10
+	if err := app.gui.SetKeybinding("services", gocui.KeyPgdn, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextService(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgdn, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextFileName(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyPgdn, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextDockerContainer(v, 10)
+	}); err != nil {
+		return err
+	}
+	// Alt+Pgdn 100
+	if err := app.gui.SetKeybinding("services", gocui.KeyPgdn, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextService(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgdn, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextFileName(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyPgdn, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.nextDockerContainer(v, 100)
+	}); err != nil {
+		return err
+	}
+	// ^^^
+	// Пролистывание вверх
+	if err := app.gui.SetKeybinding("services", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevService(v, 1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevFileName(v, 1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevDockerContainer(v, 1)
+	}); err != nil {
+		return err
+	}
+	// Shift+Up 10
+	if err := app.gui.SetKeybinding("services", gocui.KeyArrowUp, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevService(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowUp, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevFileName(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyArrowUp, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevDockerContainer(v, 10)
+	}); err != nil {
+		return err
+	}
+	// Alt+Up 100
+	if err := app.gui.SetKeybinding("services", gocui.KeyArrowUp, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevService(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowUp, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevFileName(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyArrowUp, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevDockerContainer(v, 100)
+	}); err != nil {
+		return err
+	}
+	// Shift+U на 10 для macOS
+	if err := app.gui.SetKeybinding("services", 'U', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevService(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", 'U', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevFileName(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", 'U', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevDockerContainer(v, 10)
+	}); err != nil {
+		return err
+	}
+	// Ctrl+U на 100 для macOS
+	if err := app.gui.SetKeybinding("services", gocui.KeyCtrlU, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevService(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyCtrlU, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevFileName(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyCtrlU, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevDockerContainer(v, 100)
+	}); err != nil {
+		return err
+	}
+	// Pgup 1
+	if err := app.gui.SetKeybinding("services", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevService(v, 1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevFileName(v, 1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevDockerContainer(v, 1)
+	}); err != nil {
+		return err
+	}
+	// Shift+Pgup 10
+	if err := app.gui.SetKeybinding("services", gocui.KeyPgup, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevService(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgup, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevFileName(v, 10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyPgup, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevDockerContainer(v, 10)
+	}); err != nil {
+		return err
+	}
+	// Alt+Pgup 100
+	if err := app.gui.SetKeybinding("services", gocui.KeyPgup, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevService(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgup, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevFileName(v, 100)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyPgup, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.prevDockerContainer(v, 100)
+	}); err != nil {
+		return err
+	}
+	// Переключение выбора журналов для systemd/journald и отключаем для Windows
+	if app.getOS != "windows" {
+		if err := app.gui.SetKeybinding("services", gocui.KeyArrowRight, gocui.ModNone, app.setUnitListRight); err != nil {
+			return err
+		}
+		if err := app.gui.SetKeybinding("services", gocui.KeyArrowLeft, gocui.ModNone, app.setUnitListLeft); err != nil {
+			return err
+		}
+	}
+	// Переключение выбора журналов для File System
+	if app.keybindingsEnabled {
+		// Установка привязок
+		if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowRight, gocui.ModNone, app.setLogFilesListRight); err != nil {
+			return err
+		}
+		if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowLeft, gocui.ModNone, app.setLogFilesListLeft); err != nil {
+			return err
+		}
+	} else {
+		// Удаление привязок
+		if err := app.gui.DeleteKeybinding("varLogs", gocui.KeyArrowRight, gocui.ModNone); err != nil {
+			return err
+		}
+		if err := app.gui.DeleteKeybinding("varLogs", gocui.KeyArrowLeft, gocui.ModNone); err != nil {
+			return err
+		}
+	}
+	// Переключение систем контейнеризации для Containers
+	if err := app.gui.SetKeybinding("docker", gocui.KeyArrowRight, gocui.ModNone, app.setContainersListRight); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("docker", gocui.KeyArrowLeft, gocui.ModNone, app.setContainersListLeft); err != nil {
+		return err
+	}
+	// Переключение между режимами фильтрации через Up/Down для выбранного окна (filter)
+	if err := app.gui.SetKeybinding("filter", gocui.KeyArrowUp, gocui.ModNone, app.setFilterModeRight); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("filter", gocui.KeyArrowDown, gocui.ModNone, app.setFilterModeLeft); err != nil {
+		return err
+	}
+	// PgUp/PgDn Filter
+	if err := app.gui.SetKeybinding("filter", gocui.KeyPgup, gocui.ModNone, app.setFilterModeRight); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("filter", gocui.KeyPgdn, gocui.ModNone, app.setFilterModeLeft); err != nil {
+		return err
+	}
+	// Переключение для количества выводимых строк через -/+ для выбранного окна (logs)
+	if err := app.gui.SetKeybinding("logs", '-', gocui.ModNone, app.setCountLogViewDown); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", '+', gocui.ModNone, app.setCountLogViewUp); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", '=', gocui.ModNone, app.setCountLogViewUp); err != nil {
+		return err
+	}
+	// Горизонтальная прокрутка вывода журнала через Left/Right в режиме без переноса строк
+	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowLeft, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollHorizontalLogs(-1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowRight, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollHorizontalLogs(1)
+	}); err != nil {
+		return err
+	}
+	// Включить/отключить перенос длинных строк в выводе журнала (w)
+	if err := app.gui.SetKeybinding("logs", 'w', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.toggleWrapMode()
+		return nil
+	}); err != nil {
+		return err
+	}
+	// >>> Logs
+	// Пролистывание вывода журнала через 1/10/500 записей вниз
+	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollDownLogs(1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowDown, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollDownLogs(10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowDown, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollDownLogs(500)
+	}); err != nil {
+		return err
+	}
+	// Shift+D 10
+	if err := app.gui.SetKeybinding("logs", 'D', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollDownLogs(10)
+	}); err != nil {
+		return err
+	}
+	// Ctrl+D 500
+	if err := app.gui.SetKeybinding("logs", gocui.KeyCtrlD, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollDownLogs(500)
+	}); err != nil {
+		return err
+	}
+	// Up Logs 1/10/500
+	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollUpLogs(1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowUp, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollUpLogs(10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowUp, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollUpLogs(500)
+	}); err != nil {
+		return err
+	}
+	// Shift+U 500
+	if err := app.gui.SetKeybinding("logs", 'U', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollUpLogs(10)
+	}); err != nil {
+		return err
+	}
+	// Ctrl+U 10
+	if err := app.gui.SetKeybinding("logs", gocui.KeyCtrlU, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollUpLogs(500)
+	}); err != nil {
+		return err
+	}
+	// KeyPgdn Logs 1/10/500
+	if err := app.gui.SetKeybinding("logs", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollDownLogs(1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", gocui.KeyPgdn, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollDownLogs(10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", gocui.KeyPgdn, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollDownLogs(500)
+	}); err != nil {
+		return err
+	}
+	// KeyPgup Logs 1/10/500
+	if err := app.gui.SetKeybinding("logs", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollUpLogs(1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", gocui.KeyPgup, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollUpLogs(10)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("logs", gocui.KeyPgup, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		return app.scrollUpLogs(500)
+	}); err != nil {
+		return err
+	}
+	// Перемещение к концу журнала (Ctrl+E or End)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlE, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.updateLogsView(true)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("", gocui.KeyEnd, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.updateLogsView(true)
+		return nil
+	}); err != nil {
+		return err
+	}
+	// Перемещение к началу журнала (Ctrl+A or Home)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlA, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.pageUpLogs()
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("", gocui.KeyHome, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.pageUpLogs()
+		return nil
+	}); err != nil {
+		return err
+	}
+	// Очистка поля ввода для фильтра (Ctrl+W)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlW, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.clearFilterEditor(g)
+		return nil
+	}); err != nil {
+		return err
+	}
+	// Обновить все текущие списки журналов вручную (Ctrl+R)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlR, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if app.getOS != "windows" {
+			app.loadServices(app.selectUnits)
+			app.loadFiles(app.selectPath)
+		} else {
+			app.loadWinFiles(app.selectPath)
+		}
+		app.loadDockerContainer(app.selectContainerizationSystem)
+		return nil
+	}); err != nil {
+		return err
+	}
+	// Выключение/включение встроенной покраски ключевых слов (Ctrl+Q)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlQ, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if app.colorMode {
+			app.colorMode = false
+		} else {
+			app.colorMode = true
+		}
+		if len(app.currentLogLines) != 0 {
+			app.updateLogsView(true)
+			app.applyFilter(false)
+			app.updateLogOutput(0)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	// Включение/выключение режима покраски через tailspin (Ctrl+S)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlS, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if app.tailSpinMode {
+			app.tailSpinMode = false
+		} else {
+			// Проверяем, что tailspin установлен в системе
+			tsCommands := []string{"tailspin", "tspin"}
+			for _, ts := range tsCommands {
+				cmd := localRunner{}.Command(ts, "--version")
+				_, err := cmd.Output()
+				if err == nil {
+					app.tailSpinMode = true
 				}
-				app.applyFilterList()
 			}
-			return nil
-		})
-		time.Sleep(time.Duration(seconds) * time.Second)
-	}
-}
-
-// Функция для фиксации места загрузки журнала с помощью делиметра
-func (app *App) updateDelimiter(newUpdate bool) {
-	// Фиксируем текущую длинну массива (индекс) для вставки строки обновления, если это ручной выбор из списка
-	if newUpdate {
-		app.newUpdateIndex = len(app.currentLogLines) - 1
-		// Сбрасываем автоскролл
-		app.autoScroll = true
-		// Фиксируем время загрузки журнала
-		app.updateTime = time.Now().Format("15:04:05")
-	}
-	// Проверяем, что массив не пустой и уже привысил длинну новых сообщений
-	if app.newUpdateIndex > 0 && len(app.currentLogLines)-1 > app.newUpdateIndex {
-		// Формируем длинну делимитра
-		v, _ := app.gui.View("logs")
-		width, _ := v.Size()
-		lengthDelimiter := width/2 - 5
-		delimiter1 := strings.Repeat("⎯", lengthDelimiter)
-		delimiter2 := delimiter1
-		if width > lengthDelimiter+lengthDelimiter+10 {
-			delimiter2 = strings.Repeat("⎯", lengthDelimiter+1)
 		}
-		var delimiterString string = delimiter1 + " " + app.updateTime + " " + delimiter2
-		// Вставляем новую строку после указанного индекса, сдвигая остальные строки массива
-		app.currentLogLines = append(app.currentLogLines[:app.newUpdateIndex],
-			append([]string{delimiterString}, app.currentLogLines[app.newUpdateIndex:]...)...)
+		if len(app.currentLogLines) != 0 {
+			app.updateLogsView(true)
+			app.applyFilter(false)
+			app.updateLogOutput(0)
+		}
+		return nil
+	}); err != nil {
+		return err
 	}
-}
-
-// ---------------------------------------- Key Binding ----------------------------------------
-
-// Функция для биндинга клавиш
-func (app *App) setupKeybindings() error {
-	// Ctrl+C для выхода из приложения
-	if err := app.gui.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, quit); err != nil {
+	// Развернуть/свернуть строки в формате JSON (Ctrl+J)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlJ, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.jsonExpandMode = !app.jsonExpandMode
+		if len(app.currentLogLines) != 0 {
+			app.updateLogsView(true)
+			app.applyFilter(false)
+			app.updateLogOutput(0)
+		}
+		return nil
+	}); err != nil {
 		return err
 	}
-	// Tab для переключения между окнами
-	if err := app.gui.SetKeybinding("", gocui.KeyTab, gocui.ModNone, app.nextView); err != nil {
+	// Включение/выключение объединения повторяющихся строк (Ctrl+X)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlX, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.dedupMode = !app.dedupMode
+		if len(app.currentLogLines) != 0 {
+			app.updateLogsView(true)
+			app.applyFilter(false)
+			app.updateLogOutput(0)
+		}
+		return nil
+	}); err != nil {
 		return err
 	}
-	// Shift+Tab для переключения между окнами в обратном порядке
-	if err := app.gui.SetKeybinding("", gocui.KeyBacktab, gocui.ModNone, app.backView); err != nil {
+	// Свернуть/развернуть первую многострочную запись (стек вызовов) в видимой части журнала (z)
+	if err := app.gui.SetKeybinding("logs", 'z', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, viewHeight := v.Size()
+		for i := app.logScrollPos; i <= app.logScrollPos+viewHeight && i < len(app.filteredLines); i++ {
+			if start := app.toggleGroupFold(i); start != -1 {
+				app.autoScroll = false
+				app.logScrollPos = start
+				app.refreshFilter()
+				break
+			}
+		}
+		return nil
+	}); err != nil {
 		return err
 	}
-	// Enter для выбора службы и загрузки журналов
-	if err := app.gui.SetKeybinding("services", gocui.KeyEnter, gocui.ModNone, app.selectService); err != nil {
+	// Свернуть/развернуть все многострочные записи (Z)
+	if err := app.gui.SetKeybinding("logs", 'Z', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.foldAllGroups = !app.foldAllGroups
+		app.foldedGroups = nil
+		app.refreshFilter()
+		return nil
+	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyEnter, gocui.ModNone, app.selectFile); err != nil {
+	// Показать/скрыть панель Timeline над выводом журнала (Ctrl+T)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlT, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.timelineMode = !app.timelineMode
+		app.selectedTimeline = -1
+		if !app.timelineMode {
+			app.timelineBuckets = nil
+			if v := g.CurrentView(); v != nil && v.Name() == "timeline" {
+				if err := app.focusTimeline(g, false); err != nil {
+					return err
+				}
+			}
+		}
+		// Пересчитываем интервалы после изменения размеров окон
+		g.Update(func(g *gocui.Gui) error {
+			app.refreshFilter()
+			if app.timelineMode {
+				return app.focusTimeline(g, true)
+			}
+			return nil
+		})
+		return nil
+	}); err != nil {
 		return err
 	}
-	// Перемещение вниз к следующей службе (функция nextService), файлу (nextFileName) или контейнеру (nextDockerContainer)
-	if err := app.gui.SetKeybinding("services", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextService(v, 1)
+	// Перейти из вывода журнала в панель Timeline (t)
+	if err := app.gui.SetKeybinding("logs", 't', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if !app.timelineMode {
+			return nil
+		}
+		return app.focusTimeline(g, true)
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextFileName(v, 1)
+	// Выбор интервала в панели Timeline
+	if err := app.gui.SetKeybinding("timeline", gocui.KeyArrowLeft, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if app.selectedTimeline > 0 {
+			app.selectedTimeline--
+			app.drawTimeline()
+		}
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Быстрое пролистывание вниз через 10 записей (Shift+Down)
-	if err := app.gui.SetKeybinding("services", gocui.KeyArrowDown, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextService(v, 10)
+	if err := app.gui.SetKeybinding("timeline", gocui.KeyArrowRight, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if app.selectedTimeline < len(app.timelineBuckets)-1 {
+			app.selectedTimeline++
+			app.drawTimeline()
+		}
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowDown, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextFileName(v, 10)
+	// Переход к выбранному интервалу в выводе журнала (Enter)
+	if err := app.gui.SetKeybinding("timeline", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.jumpToTimeline()
+		return app.focusTimeline(g, false)
 	}); err != nil {
 		return err
 	}
-	// Alt+Down 100
-	if err := app.gui.SetKeybinding("services", gocui.KeyArrowDown, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextService(v, 100)
+	// Окно шаблонов сообщений текущего журнала (Ctrl+P)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlP, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showPatterns(g)
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowDown, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextFileName(v, 100)
+	// Перемещение по списку шаблонов
+	if err := app.gui.SetKeybinding("patterns", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.movePopupCursor(v, 1, len(app.logPatterns))
 	}); err != nil {
 		return err
 	}
-	// Shift+D на 10 для macOS
-	if err := app.gui.SetKeybinding("services", 'D', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextService(v, 10)
+	if err := app.gui.SetKeybinding("patterns", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.movePopupCursor(v, -1, len(app.logPatterns))
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", 'D', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextFileName(v, 10)
+	if err := app.gui.SetKeybinding("patterns", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, viewHeight := v.Size()
+		return app.movePopupCursor(v, viewHeight, len(app.logPatterns))
 	}); err != nil {
 		return err
 	}
-	// Ctrl+D на 100 для macOS
-	if err := app.gui.SetKeybinding("services", gocui.KeyCtrlD, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextService(v, 100)
+	if err := app.gui.SetKeybinding("patterns", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, viewHeight := v.Size()
+		return app.movePopupCursor(v, -viewHeight, len(app.logPatterns))
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyCtrlD, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextFileName(v, 100)
+	// Фильтрация вывода по выбранному шаблону (Enter)
+	if err := app.gui.SetKeybinding("patterns", gocui.KeyEnter, gocui.ModNone, app.selectPattern); err != nil {
+		return err
+	}
+	// Окно сравнения активного журнала со вторым окном или соседней вкладкой (F9)
+	if err := app.gui.SetKeybinding("", gocui.KeyF9, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showDiff(g)
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Pgdn 1
-	if err := app.gui.SetKeybinding("services", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextService(v, 1)
+	// Прокрутка окна сравнения
+	if err := app.gui.SetKeybinding("diff", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.movePopupCursor(v, 1, app.diffLines)
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextFileName(v, 1)
+	if err := app.gui.SetKeybinding("diff", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.movePopupCursor(v, -1, app.diffLines)
 	}); err != nil {
 		return err
 	}
-	// Shift+Pgdn 10
-	if err := app.gui.SetKeybinding("services", gocui.KeyPgdn, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextService(v, 10)
+	if err := app.gui.SetKeybinding("diff", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, viewHeight := v.Size()
+		return app.movePopupCursor(v, viewHeight, app.diffLines)
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgdn, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextFileName(v, 10)
+	if err := app.gui.SetKeybinding("diff", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		_, viewHeight := v.Size()
+		return app.movePopupCursor(v, -viewHeight, app.diffLines)
 	}); err != nil {
 		return err
 	}
-	// Alt+Pgdn 100
-	if err := app.gui.SetKeybinding("services", gocui.KeyPgdn, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextService(v, 100)
+	// Окно правил оповещений и срабатываний (Ctrl+L)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlL, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showAlerts(g)
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgdn, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.nextFileName(v, 100)
+	// Перемещение по окну оповещений, добавление правила из текста фильтра (a) и удаление (d)
+	if err := app.gui.SetKeybinding("alerts", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.movePopupCursor(v, 1, len(app.alertRules)+len(app.alertHits))
 	}); err != nil {
 		return err
 	}
-	// ^^^
-	// Пролистывание вверх
-	if err := app.gui.SetKeybinding("services", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevService(v, 1)
+	if err := app.gui.SetKeybinding("alerts", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.movePopupCursor(v, -1, len(app.alertRules)+len(app.alertHits))
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevFileName(v, 1)
+	if err := app.gui.SetKeybinding("alerts", 'a', gocui.ModNone, app.addAlertRule); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("alerts", 'd', gocui.ModNone, app.deleteAlertItem); err != nil {
+		return err
+	}
+	// Окно переключения удаленных хостов (Ctrl+O)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlO, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showHosts(g)
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Shift+Up 10
-	if err := app.gui.SetKeybinding("services", gocui.KeyArrowUp, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevService(v, 10)
+	if err := app.gui.SetKeybinding("hosts", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.movePopupCursor(v, 1, len(app.hostList()))
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowUp, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevFileName(v, 10)
+	if err := app.gui.SetKeybinding("hosts", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.movePopupCursor(v, -1, len(app.hostList()))
 	}); err != nil {
 		return err
 	}
-	// Alt+Up 100
-	if err := app.gui.SetKeybinding("services", gocui.KeyArrowUp, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevService(v, 100)
+	if err := app.gui.SetKeybinding("hosts", gocui.KeyEnter, gocui.ModNone, app.selectHost); err != nil {
+		return err
+	}
+	// Закрепить/открепить журнал для проверки правил оповещений в фоне (a)
+	if err := app.gui.SetKeybinding("logs", 'a', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.togglePinned()
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowUp, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevFileName(v, 100)
+	// Добавить/удалить закладку на первой видимой строке вывода журнала (m)
+	if err := app.gui.SetKeybinding("logs", 'm', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.toggleBookmark(app.logScrollPos)
+		app.refreshFilter()
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Shift+U на 10 для macOS
-	if err := app.gui.SetKeybinding("services", 'U', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevService(v, 10)
+	// Добавить/изменить заметку к первой видимой строке (n)
+	if err := app.gui.SetKeybinding("logs", 'n', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showNote(g, app.logScrollPos)
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", 'U', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevFileName(v, 10)
+	if err := app.gui.SetKeybinding("note", gocui.KeyEnter, gocui.ModNone, app.saveNote); err != nil {
+		return err
+	}
+	// Показать/скрыть номера строк исходного журнала (Ctrl+N)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlN, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.lineNumbers = !app.lineNumbers
+		app.refreshFilter()
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Ctrl+U на 100 для macOS
-	if err := app.gui.SetKeybinding("services", gocui.KeyCtrlU, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevService(v, 100)
+	// Перейти к строке с номером в полном выводе журнала без фильтра (g)
+	if err := app.gui.SetKeybinding("logs", 'g', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showGoToLine(g)
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyCtrlU, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevFileName(v, 100)
+	if err := app.gui.SetKeybinding("goToLine", gocui.KeyEnter, gocui.ModNone, app.goToLine); err != nil {
+		return err
+	}
+	// Показать первую видимую строку в полном выводе журнала без фильтра (c)
+	if err := app.gui.SetKeybinding("logs", 'c', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showInContext(g)
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Pgup 1
-	if err := app.gui.SetKeybinding("services", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevService(v, 1)
+	// Перейти к предыдущей/следующей закладке ([ и ])
+	if err := app.gui.SetKeybinding("logs", '[', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.scrollToLine(app.nearBookmark(app.logScrollPos, -1))
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevFileName(v, 1)
+	if err := app.gui.SetKeybinding("logs", ']', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.scrollToLine(app.nearBookmark(app.logScrollPos, 1))
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Shift+Pgup 10
-	if err := app.gui.SetKeybinding("services", gocui.KeyPgup, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevService(v, 10)
+	// Окно со списком закладок (Ctrl+B)
+	if err := app.gui.SetKeybinding("", gocui.KeyCtrlB, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showBookmarks(g)
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgup, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevFileName(v, 10)
+	// Перемещение по списку закладок
+	if err := app.gui.SetKeybinding("bookmarks", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.movePopupCursor(v, 1, len(app.bookmarkList))
 	}); err != nil {
 		return err
 	}
-	// Alt+Pgup 100
-	if err := app.gui.SetKeybinding("services", gocui.KeyPgup, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevService(v, 100)
+	if err := app.gui.SetKeybinding("bookmarks", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.movePopupCursor(v, -1, len(app.bookmarkList))
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("varLogs", gocui.KeyPgup, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.prevFileName(v, 100)
+	// Переход к выбранной закладке (Enter) и удаление закладки (d)
+	if err := app.gui.SetKeybinding("bookmarks", gocui.KeyEnter, gocui.ModNone, app.selectBookmark); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("bookmarks", 'd', gocui.ModNone, app.deleteBookmark); err != nil {
+		return err
+	}
+	// Включить/отменить выделение строк с первой видимой строки (v)
+	if err := app.gui.SetKeybinding("logs", 'v', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.toggleSelection()
+		app.updateLogsView(false)
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Переключение выбора журналов для systemd/journald и отключаем для Windows
-	if app.getOS != "windows" {
-		if err := app.gui.SetKeybinding("services", gocui.KeyArrowRight, gocui.ModNone, app.setUnitListRight); err != nil {
-			return err
-		}
-		if err := app.gui.SetKeybinding("services", gocui.KeyArrowLeft, gocui.ModNone, app.setUnitListLeft); err != nil {
-			return err
+	// Переключить перемещаемый конец выделения (o)
+	if err := app.gui.SetKeybinding("logs", 'o', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if app.selectMode {
+			app.selectStart, app.selectEnd = app.selectEnd, app.selectStart
+			return app.moveSelection(0)
 		}
+		return nil
+	}); err != nil {
+		return err
 	}
-	// Переключение выбора журналов для File System
-	if app.keybindingsEnabled {
-		// Установка привязок
-		if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowRight, gocui.ModNone, app.setLogFilesListRight); err != nil {
-			return err
-		}
-		if err := app.gui.SetKeybinding("varLogs", gocui.KeyArrowLeft, gocui.ModNone, app.setLogFilesListLeft); err != nil {
-			return err
+	// Скопировать выделенные строки или первую видимую строку в буфер обмена (y)
+	if err := app.gui.SetKeybinding("logs", 'y', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		text := app.selectedText()
+		if text == "" {
+			return nil
 		}
-	} else {
-		// Удаление привязок
-		if err := app.gui.DeleteKeybinding("varLogs", gocui.KeyArrowRight, gocui.ModNone); err != nil {
-			return err
+		if err := copyToClipboard(text); err != nil {
+			v.Title += " [Copy error: " + err.Error() + "]"
+			return nil
 		}
-		if err := app.gui.DeleteKeybinding("varLogs", gocui.KeyArrowLeft, gocui.ModNone); err != nil {
+		app.selectMode = false
+		app.updateLogsView(false)
+		return nil
+	}); err != nil {
+		return err
+	}
+	// Открыть журнал на первой видимой строке в $EDITOR (e) или $PAGER (p)
+	for key, editor := range map[rune]bool{'e': true, 'p': false} {
+		if err := app.gui.SetKeybinding("logs", key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			if err := app.openExternalView(g, editor); err != nil {
+				v.Title += " [Open error: " + err.Error() + "]"
+			}
+			return nil
+		}); err != nil {
 			return err
 		}
 	}
-	// Переключение между режимами фильтрации через Up/Down для выбранного окна (filter)
-	if err := app.gui.SetKeybinding("filter", gocui.KeyArrowUp, gocui.ModNone, app.setFilterModeRight); err != nil {
+	// Окно выгрузки текущего вывода журнала в файл (F3)
+	if err := app.gui.SetKeybinding("", gocui.KeyF3, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showExport(g)
+		return nil
+	}); err != nil {
+		return err
+	}
+	// Переключение формата выгрузки
+	if err := app.gui.SetKeybinding("export", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.switchExportFormat(v, 1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("export", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.switchExportFormat(v, -1)
+	}); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("export", gocui.KeyEnter, gocui.ModNone, app.saveExport); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("filter", gocui.KeyArrowDown, gocui.ModNone, app.setFilterModeLeft); err != nil {
+	// Окно статистики текущего вывода журнала (F2)
+	if err := app.gui.SetKeybinding("", gocui.KeyF2, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showStats(g)
+		return nil
+	}); err != nil {
 		return err
 	}
-	// PgUp/PgDn Filter
-	if err := app.gui.SetKeybinding("filter", gocui.KeyPgup, gocui.ModNone, app.setFilterModeRight); err != nil {
+	// Отключить окно справки (F1)
+	if err := app.gui.SetKeybinding("", gocui.KeyF1, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.showInterfaceHelp(g)
+		return nil
+	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("filter", gocui.KeyPgdn, gocui.ModNone, app.setFilterModeLeft); err != nil {
+	// Полноэкранный режим вывода журнала (F4)
+	if err := app.gui.SetKeybinding("", gocui.KeyF4, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.toggleZoomLogs(g)
+	}); err != nil {
 		return err
 	}
-	// Переключение для количества выводимых строк через Left/Right для выбранного окна (logs)
-	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowLeft, gocui.ModNone, app.setCountLogViewDown); err != nil {
+	// Переключение расположения окон: списки слева или снизу (F5)
+	if err := app.gui.SetKeybinding("", gocui.KeyF5, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.switchLayoutPreset()
+		return nil
+	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowRight, gocui.ModNone, app.setCountLogViewUp); err != nil {
+	// Открыть/закрыть второе окно вывода журнала для сравнения (F6)
+	if err := app.gui.SetKeybinding("", gocui.KeyF6, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.toggleSplitView(g)
+	}); err != nil {
 		return err
 	}
-	// >>> Logs
-	// Пролистывание вывода журнала через 1/10/500 записей вниз
-	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollDownLogs(1)
+	// Переключение активного окна вывода журнала в режиме сравнения (F7)
+	if err := app.gui.SetKeybinding("", gocui.KeyF7, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		return app.switchLogPane(g)
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowDown, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollDownLogs(10)
+	// Переключение вкладок (< и >, 1-9), перемещение активной вкладки ({ и }) и закрытие вкладки (x)
+	if err := app.gui.SetKeybinding("logs", '<', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.nextTab(-1)
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowDown, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollDownLogs(500)
+	if err := app.gui.SetKeybinding("logs", '>', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.nextTab(1)
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Shift+D 10
-	if err := app.gui.SetKeybinding("logs", 'D', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollDownLogs(10)
+	for i := range 9 {
+		if err := app.gui.SetKeybinding("logs", rune('1'+i), gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+			app.switchTab(i)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	if err := app.gui.SetKeybinding("logs", '{', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.moveTab(-1)
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Ctrl+D 500
-	if err := app.gui.SetKeybinding("logs", gocui.KeyCtrlD, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollDownLogs(500)
+	if err := app.gui.SetKeybinding("logs", '}', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.moveTab(1)
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Up Logs 1/10/500
-	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollUpLogs(1)
+	if err := app.gui.SetKeybinding("logs", 'x', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.closeTab()
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowUp, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollUpLogs(10)
+	// Синхронная прокрутка окон по времени строк (F8)
+	if err := app.gui.SetKeybinding("", gocui.KeyF8, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		app.toggleSyncScroll()
+		return nil
 	}); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("logs", gocui.KeyArrowUp, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollUpLogs(500)
+	// Уменьшить/увеличить панель списков журналов (Alt+Left/Alt+Right)
+	if err := app.gui.SetKeybinding("", gocui.KeyArrowLeft, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		app.resizeListsPanel(g, -1)
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Shift+U 500
-	if err := app.gui.SetKeybinding("logs", 'U', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollUpLogs(10)
+	if err := app.gui.SetKeybinding("", gocui.KeyArrowRight, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
+		app.resizeListsPanel(g, 1)
+		return nil
 	}); err != nil {
 		return err
 	}
-	// Ctrl+U 10
-	if err := app.gui.SetKeybinding("logs", gocui.KeyCtrlU, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollUpLogs(500)
+	// Мышь: выбор окна и элемента списка, перетаскивание границы колонок и полосы прокрутки, прокрутка колесом
+	if err := app.gui.SetKeybinding("", gocui.MouseLeft, gocui.ModNone, app.mouseClick); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("", gocui.MouseRelease, gocui.ModNone, app.mouseRelease); err != nil {
+		return err
+	}
+	// Перемещение мыши (в том числе с нажатой кнопкой) передается без клавиши,
+	// оно обрабатывается только в окнах с курсором выбора (при перетаскивании - во всех окнах)
+	for _, name := range mouseCursorViews {
+		if err := app.gui.SetKeybinding(name, gocui.Key(0), gocui.ModNone, app.mouseMove); err != nil {
+			return err
+		}
+	}
+	if err := app.gui.SetKeybinding("", gocui.MouseWheelDown, gocui.ModNone, app.mouseWheel(1)); err != nil {
+		return err
+	}
+	if err := app.gui.SetKeybinding("", gocui.MouseWheelUp, gocui.ModNone, app.mouseWheel(-1)); err != nil {
+		return err
+	}
+	// Закрыть окно справки (Esc)
+	if err := app.gui.SetKeybinding("", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if err := app.closePatterns(g); err == nil {
+			return nil
+		}
+		if err := app.closeStats(g); err == nil {
+			return nil
+		}
+		if err := app.closeBookmarks(g); err == nil {
+			return nil
+		}
+		if err := app.closeNote(g); err == nil {
+			return nil
+		}
+		if err := app.closeExport(g); err == nil {
+			return nil
+		}
+		if err := app.closeGoToLine(g); err == nil {
+			return nil
+		}
+		if err := app.closeDiff(g); err == nil {
+			return nil
+		}
+		if err := app.closeAlerts(g); err == nil {
+			return nil
+		}
+		if err := app.closeHosts(g); err == nil {
+			return nil
+		}
+		if app.selectMode {
+			app.selectMode = false
+			app.updateLogsView(false)
+			return nil
+		}
+		if v := g.CurrentView(); v != nil && v.Name() == "timeline" {
+			return app.focusTimeline(g, false)
+		}
+		if err := app.closeHelp(g); err != nil {
+			return nil
+		}
+		return nil
 	}); err != nil {
 		return err
 	}
-	// KeyPgdn Logs 1/10/500
-	if err := app.gui.SetKeybinding("logs", gocui.KeyPgdn, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollDownLogs(1)
-	}); err != nil {
+	return nil
+}
+
+func (app *App) showInterfaceHelp(g *gocui.Gui) {
+	// Получаем размеры терминала
+	maxX, maxY := g.Size()
+	// Размеры окна help
+	width, height := 104, 60
+	// Вычисляем координаты для центрального расположения
+	x0 := (maxX - width) / 2
+	y0 := (maxY - height) / 2
+	x1 := x0 + width
+	y1 := y0 + height
+	helpView, err := g.SetView("help", x0, y0, x1, y1, 0)
+	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+		return
+	}
+	helpView.Title = " Help "
+	helpView.Autoscroll = true
+	helpView.Wrap = true
+	helpView.FrameColor = gocui.ColorGreen
+	helpView.TitleColor = gocui.ColorGreen
+	helpView.Clear()
+	fmt.Fprintln(helpView, "\n  \033[33mlazyjournal\033[0m - terminal user interface for reading logs from journalctl, file system, Docker and")
+	fmt.Fprintln(helpView, "  Podman containers, as well Kubernetes pods.")
+	fmt.Fprintln(helpView, "\n  Version: \033[36m"+programVersion+"\033[0m")
+	fmt.Fprintln(helpView, "\n  Hotkeys:")
+	fmt.Fprintln(helpView, "\n  \033[32mF2\033[0m - show statistics for the current log output.")
+	fmt.Fprintln(helpView, "  \033[32mF3\033[0m - export the current log output to a file (text, ANSI, HTML or JSON lines).")
+	fmt.Fprintln(helpView, "  \033[32mTab\033[0m - switch between windows.")
+	fmt.Fprintln(helpView, "  \033[32mShift+Tab\033[0m - return to previous window.")
+	fmt.Fprintln(helpView, "  \033[32mLeft/Right\033[0m - switch between journal lists in the selected window.")
+	fmt.Fprintln(helpView, "  \033[32mEnter\033[0m - selection a journal from the list to display log output.")
+	fmt.Fprintln(helpView, "  \033[32m<Up/PgUp>\033[0m and \033[32m<Down/PgDown>\033[0m - move up and down through all journal lists and log output,")
+	fmt.Fprintln(helpView, "  as well as changing the filtering mode in the filter window.")
+	fmt.Fprintln(helpView, "  \033[32m<Shift/Alt>+<Up/Down>\033[0m - quickly move up and down through all journal lists and log output")
+	fmt.Fprintln(helpView, "  every 10 or 100 lines (500 for log output).")
+	fmt.Fprintln(helpView, "  \033[32m<Shift/Ctrl>+<U/D>\033[0m - quickly move up and down (alternative for macOS).")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+A\033[0m or \033[32mHome\033[0m - go to top of log.")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+E\033[0m or \033[32mEnd\033[0m - go to the end of the log.")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+Q\033[0m - enable or disable built-in output coloring.")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+S\033[0m - enable or disable coloring via tailspin.")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+J\033[0m - expand or collapse JSON lines (pretty-print or compact view).")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+X\033[0m - enable or disable collapsing of repeated lines into one line with the number of repeats.")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+T\033[0m - show or hide the timeline of log volume and errors over time, \033[32mt\033[0m in the log output switches")
+	fmt.Fprintln(helpView, "  to the timeline, \033[32mLeft/Right\033[0m select an interval and \033[32mEnter\033[0m goes to it in the log output.")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+P\033[0m - show message patterns of the current log, Enter filters the output by the selected pattern.")
+	fmt.Fprintln(helpView, "  \033[32mz\033[0m and \033[32mZ\033[0m - fold or unfold the first multi-line entry (stack trace) in the log output or all entries.")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+R\033[0m - update all log lists.")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+W\033[0m - clear text input field for filter (and the selected pattern) to quickly update current log output")
+	fmt.Fprintln(helpView, "  without filtering.")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+C\033[0m - exit.")
+	fmt.Fprintln(helpView, "  \033[32mm\033[0m - add or remove a bookmark on the first visible line, \033[32mn\033[0m - edit its note.")
+	fmt.Fprintln(helpView, "  \033[32m[\033[0m and \033[32m]\033[0m - go to the previous or next bookmark.")
+	fmt.Fprintln(helpView, "  \033[32mv\033[0m - select lines with the arrow keys (\033[32mo\033[0m - move the other end), \033[32my\033[0m - copy selected lines to the clipboard.")
+	fmt.Fprintln(helpView, "  \033[32me\033[0m and \033[32mp\033[0m - open the log at the first visible line in $EDITOR or $PAGER (less by default).")
+	fmt.Fprintln(helpView, "  \033[32mw\033[0m - disable or enable wrapping of long lines, \033[32mLeft/Right\033[0m - scroll the log output horizontally without wrapping.")
+	fmt.Fprintln(helpView, "  \033[32m-\033[0m and \033[32m+\033[0m - decrease or increase the number of log lines to load (5000-300000).")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+N\033[0m - show or hide line numbers of the original log.")
+	fmt.Fprintln(helpView, "  \033[32mg\033[0m - go to line number, \033[32mc\033[0m - show the first visible line in the unfiltered log (the filter is cleared).")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+B\033[0m - list bookmarks saved in the session file (Enter - go to line, d - delete).")
+	fmt.Fprintln(helpView, "  \033[32mF4\033[0m - show the log output in full screen, \033[32mF5\033[0m - switch the layout (lists on the left or at the bottom).")
+	fmt.Fprintln(helpView, "  \033[32mAlt+Left/Alt+Right\033[0m - shrink or grow the panel with lists.")
+	fmt.Fprintln(helpView, "  \033[32mF6\033[0m - open or close a second log output to compare logs, \033[32mF7\033[0m - switch the active log output,")
+	fmt.Fprintln(helpView, "  \033[32mF8\033[0m - scroll the second log output to the time of the first visible line of the active one.")
+	fmt.Fprintln(helpView, "  \033[32m<\033[0m and \033[32m>\033[0m (or \033[32m1-9\033[0m) - switch tabs of opened logs, \033[32m{\033[0m and \033[32m}\033[0m - move the tab, \033[32mx\033[0m - close the tab.")
+	fmt.Fprintln(helpView, "  \033[32mF9\033[0m - compare the active log with the second log output or the neighboring tab (lines and template counts).")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+L\033[0m - list alert rules and hits (a - add the filter text as a rule in the severity:regex format, d - delete),")
+	fmt.Fprintln(helpView, "  \033[32ma\033[0m - pin the log to check new lines for alerts when it is in a background tab or the second log output.")
+	fmt.Fprintln(helpView, "  \033[32mCtrl+O\033[0m - switch the host for log lists between the local host and remote hosts over SSH (--host flag).")
+	fmt.Fprintln(helpView, "  \033[32mf\033[0m - list log files inside the selected container or pod in the file system window.")
+	fmt.Fprintln(helpView, "  \033[32mBackspace\033[0m - return to the previous level of the Kubernetes list (namespaces, pods, containers),")
+	fmt.Fprintln(helpView, "  \033[32mP\033[0m - switch the pod log between the current and the previous (crashed) container instance.")
+	fmt.Fprintln(helpView, "  \033[32mMouse\033[0m (--mouse flag) - click to select a window or log, wheel to scroll, drag the scroll bar or the left column border.")
+	fmt.Fprintln(helpView, "  \033[32mEscape\033[0m - close help and other popup windows.")
+	fmt.Fprintln(helpView, "\n  Source code: \033[35mhttps://github.com/Lifailon/lazyjournal\033[0m")
+}
+
+// Функция для пересчета интервалов панели Timeline по текущему выводу журнала
+func (app *App) updateTimeline() {
+	width := 0
+	if !app.noGui() {
+		if v, err := app.gui.View("timeline"); err == nil {
+			width, _ = v.Size()
+		}
+	}
+	if width <= 0 {
+		width = 60
+	}
+	// Делимитр обновления и пустая строка в конце не относятся к журналу,
+	// индексы первых строк интервалов переводятся обратно в позиции вывода
+	var lines []string
+	var positions []int
+	for i, line := range app.filteredLines {
+		if line.index < 0 || app.isDelimiterLine(line.index) || i >= len(app.filteredLogLines) {
+			continue
+		}
+		lines = append(lines, app.filteredLogLines[i])
+		positions = append(positions, i)
+	}
+	app.timelineBuckets, app.timelineUnit = buildTimeline(lines, width)
+	for i := range app.timelineBuckets {
+		if first := app.timelineBuckets[i].firstLine; first >= 0 {
+			app.timelineBuckets[i].firstLine = positions[first]
+		}
+	}
+	// По умолчанию выбран последний интервал
+	if app.selectedTimeline < 0 || app.selectedTimeline >= len(app.timelineBuckets) {
+		app.selectedTimeline = len(app.timelineBuckets) - 1
+	}
+}
+
+// Функция для отрисовки гистограммы в панели Timeline (строки с ошибками выделяются красным в нижней части столбца)
+func (app *App) drawTimeline() {
+	v, err := app.gui.View("timeline")
+	if err != nil {
+		return
+	}
+	v.Clear()
+	_, viewHeight := v.Size()
+	if len(app.timelineBuckets) == 0 {
+		v.Title = "Timeline (timestamps not found)"
+		return
+	}
+	maxTotal := 0
+	for _, bucket := range app.timelineBuckets {
+		maxTotal = max(maxTotal, bucket.total)
+	}
+	format := "15:04"
+	unitName := "minute"
+	switch {
+	case app.timelineUnit == time.Hour:
+		format, unitName = "02.01 15:04", "hour"
+	case app.timelineUnit == 24*time.Hour:
+		format, unitName = "02.01.2006", "day"
+	case app.timelineUnit > 24*time.Hour:
+		format, unitName = "02.01.2006", fmt.Sprintf("%d days", app.timelineUnit/(24*time.Hour))
+	}
+	selected := app.timelineBuckets[app.selectedTimeline]
+	v.Title = fmt.Sprintf("Timeline (per %s): %s - %d lines, %d errors", unitName, selected.start.Format(format), selected.total, selected.errors)
+	// Высота столбцов с округлением в большую сторону
+	barHeight := func(value int) int {
+		if maxTotal == 0 {
+			return 0
+		}
+		return (value*viewHeight + maxTotal - 1) / maxTotal
+	}
+	for row := viewHeight; row > 0; row-- {
+		var line strings.Builder
+		for i, bucket := range app.timelineBuckets {
+			var color string
+			switch {
+			case barHeight(bucket.errors) >= row:
+				color = "\033[31m"
+			case barHeight(bucket.total) >= row:
+				color = "\033[32m"
+			}
+			switch {
+			case i == app.selectedTimeline && color != "":
+				line.WriteString("\033[33m█\033[0m")
+			case i == app.selectedTimeline && row == 1:
+				line.WriteString("\033[33m▁\033[0m")
+			case color != "":
+				line.WriteString(color + "█\033[0m")
+			default:
+				line.WriteString(" ")
+			}
+		}
+		fmt.Fprintln(v, line.String())
+	}
+}
+
+// Функция для перехода к первой строке выбранного интервала в выводе журнала
+func (app *App) jumpToTimeline() {
+	if app.selectedTimeline < 0 || app.selectedTimeline >= len(app.timelineBuckets) {
+		return
+	}
+	app.scrollToLine(app.timelineBuckets[app.selectedTimeline].firstLine)
+}
+
+// Функция для прокрутки вывода журнала к строке (строка выводится первой, если это не конец журнала)
+func (app *App) scrollToLine(index int) {
+	if index < 0 || index >= len(app.filteredLogLines) {
+		return
+	}
+	app.autoScroll = false
+	app.logScrollPos = index
+	if v, err := app.gui.View(app.logsViewName()); err == nil {
+		_, viewHeight := v.Size()
+		if app.logScrollPos > len(app.filteredLogLines)-1-viewHeight {
+			app.logScrollPos = max(0, len(app.filteredLogLines)-1-viewHeight)
+		}
+	}
+	app.updateLogsView(false)
+}
+
+// Функция для переключения активного окна между выводом журнала и панелью Timeline
+func (app *App) focusTimeline(g *gocui.Gui, focus bool) error {
+	nextView := "logs"
+	if focus {
+		nextView = "timeline"
+	}
+	for _, name := range []string{"logs", "scrollLogs", "timeline"} {
+		if v, err := g.View(name); err == nil {
+			active := (name == "timeline") == focus
+			v.FrameColor = map[bool]gocui.Attribute{true: gocui.ColorGreen, false: gocui.ColorDefault}[active]
+			v.TitleColor = v.FrameColor
+		}
+	}
+	if _, err := g.SetCurrentView(nextView); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Функция для вывода двух списков рядом (название и количество) с заголовками
+func formatTopColumns(leftTitle string, left []countItem, rightTitle string, right []countItem, width int) []string {
+	nameWidth := width - 10
+	column := func(items []countItem, index int) string {
+		if index >= len(items) {
+			return strings.Repeat(" ", width)
+		}
+		name := items[index].name
+		if len([]rune(name)) > nameWidth {
+			name = string([]rune(name)[:nameWidth-1]) + "…"
+		}
+		return fmt.Sprintf("\033[32m%7d\033[0m  %-*s ", items[index].count, nameWidth, name)
+	}
+	lines := []string{fmt.Sprintf("  \033[33m%-*s\033[0m  \033[33m%s\033[0m", width, leftTitle, rightTitle)}
+	for i := 0; i < max(len(left), len(right), 1); i++ {
+		if i >= len(left) && i >= len(right) {
+			lines = append(lines, "  "+fmt.Sprintf("%-*s", width, "     -")+"       -")
+			break
+		}
+		lines = append(lines, "  "+column(left, i)+" "+column(right, i))
+	}
+	return lines
+}
+
+// Функция для вывода окна со статистикой текущего вывода журнала
+func (app *App) showStats(g *gocui.Gui) {
+	// Статистика считается по строкам вывода без номеров строк, пометок и делимитра обновления
+	stats := app.buildLogStats(app.outputLineTexts())
+	var statsText []string
+	statsText = append(statsText,
+		"",
+		fmt.Sprintf("  Total lines: \033[36m%d\033[0m", stats.total),
+		fmt.Sprintf("  Severity: \033[31merror %d\033[0m, \033[33mwarning %d\033[0m, \033[36minfo %d\033[0m, \033[34mdebug %d\033[0m, other %d",
+			stats.severities["error"], stats.severities["warning"], stats.severities["info"], stats.severities["debug"], stats.severities[""]),
+	)
+	if stats.first.IsZero() {
+		statsText = append(statsText, "  Time range: timestamps not found")
+	} else {
+		statsText = append(statsText,
+			fmt.Sprintf("  Time range: \033[36m%s\033[0m - \033[36m%s\033[0m (%s)", stats.first.Format("02.01.2006 15:04:05"), stats.last.Format("02.01.2006 15:04:05"), stats.last.Sub(stats.first).Truncate(time.Second)),
+			fmt.Sprintf("  Lines per minute: \033[36m%.1f\033[0m", stats.linesPerMinute()),
+		)
+	}
+	statsText = append(statsText, "")
+	statsText = append(statsText, formatTopColumns("Top IP addresses", topCounts(stats.ips, 10), "Top units and processes", topCounts(stats.units, 10), 48)...)
+	statsText = append(statsText, "")
+	statsText = append(statsText, formatTopColumns("Top URLs", topCounts(stats.urls, 10), "Top users", topCounts(stats.users, 10), 48)...)
+	// Размеры окна по содержимому с учетом размера терминала
+	maxX, maxY := g.Size()
+	width, height := 104, min(len(statsText)+1, maxY-2)
+	x0 := (maxX - width) / 2
+	y0 := (maxY - height) / 2
+	statsView, err := g.SetView("stats", x0, y0, x0+width, y0+height, 0)
+	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+		return
+	}
+	statsView.Title = " Statistics "
+	statsView.Wrap = false
+	statsView.FrameColor = gocui.ColorGreen
+	statsView.TitleColor = gocui.ColorGreen
+	statsView.Clear()
+	for _, line := range statsText {
+		fmt.Fprintln(statsView, line)
+	}
+}
+
+// Функция для закрытия окна статистики
+func (app *App) closeStats(g *gocui.Gui) error {
+	return g.DeleteView("stats")
+}
+
+// Функция для вывода окна со списком закладок (сначала закладки текущего источника)
+func (app *App) showBookmarks(g *gocui.Gui) {
+	source := app.logSource()
+	app.bookmarkList = nil
+	for i, mark := range app.bookmarks {
+		if mark.Source == source {
+			app.bookmarkList = append(app.bookmarkList, i)
+		}
+	}
+	for i, mark := range app.bookmarks {
+		if mark.Source != source {
+			app.bookmarkList = append(app.bookmarkList, i)
+		}
+	}
+	maxX, maxY := g.Size()
+	bookmarksView, err := g.SetView("bookmarks", 4, 2, maxX-5, maxY-3, 0)
+	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+		return
+	}
+	bookmarksView.Title = fmt.Sprintf(" Bookmarks (%d): Enter - go to line, d - delete ", len(app.bookmarks))
+	bookmarksView.Highlight = true
+	bookmarksView.Wrap = false
+	bookmarksView.Autoscroll = false
+	bookmarksView.FrameColor = gocui.ColorGreen
+	bookmarksView.TitleColor = gocui.ColorGreen
+	bookmarksView.SelBgColor = gocui.ColorGreen
+	bookmarksView.SelFgColor = gocui.ColorBlack
+	bookmarksView.Clear()
+	for _, index := range app.bookmarkList {
+		mark := app.bookmarks[index]
+		line := mark.Line
+		if mark.Note != "" {
+			line = "\033[33m" + mark.Note + ":\033[0m " + line
+		}
+		// Для закладок других источников выводим источник
+		if mark.Source != source {
+			line = "\033[90m" + mark.Source + "\033[0m " + line
+		}
+		fmt.Fprintln(bookmarksView, " ★ "+line)
+	}
+	_, originY := bookmarksView.Origin()
+	_, cursorY := bookmarksView.Cursor()
+	if originY+cursorY >= len(app.bookmarkList) {
+		_ = bookmarksView.SetOrigin(0, 0)
+		_ = bookmarksView.SetCursor(0, 0)
+	}
+	if _, err := g.SetCurrentView("bookmarks"); err != nil {
+		return
+	}
+}
+
+// Функция для перехода к выбранной закладке текущего источника в выводе журнала
+func (app *App) selectBookmark(g *gocui.Gui, v *gocui.View) error {
+	_, originY := v.Origin()
+	_, cursorY := v.Cursor()
+	index := originY + cursorY
+	if index >= len(app.bookmarkList) {
+		return nil
+	}
+	mark := app.bookmarks[app.bookmarkList[index]]
+	if mark.Source != app.logSource() {
+		return nil
+	}
+	mark.Note = ""
+	for _, lineIndex := range app.bookmarkedLines {
+		if current, ok := app.outputBookmark(lineIndex); ok && current == mark {
+			if err := app.closeBookmarks(g); err != nil {
+				return err
+			}
+			app.scrollToLine(lineIndex)
+			return nil
+		}
+	}
+	return nil
+}
+
+// Функция для удаления выбранной закладки из списка
+func (app *App) deleteBookmark(g *gocui.Gui, v *gocui.View) error {
+	_, originY := v.Origin()
+	_, cursorY := v.Cursor()
+	index := originY + cursorY
+	if index >= len(app.bookmarkList) {
+		return nil
+	}
+	bookmarkIndex := app.bookmarkList[index]
+	app.bookmarks = slices.Delete(app.bookmarks, bookmarkIndex, bookmarkIndex+1)
+	app.saveSessionFile()
+	app.showBookmarks(g)
+	app.refreshFilter()
+	return nil
+}
+
+// Функция для закрытия окна закладок и возврата к выводу журнала
+func (app *App) closeBookmarks(g *gocui.Gui) error {
+	if err := g.DeleteView("bookmarks"); err != nil {
+		return err
+	}
+	if _, err := g.SetCurrentView("logs"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Функция для вывода окна ввода заметки к строке вывода журнала
+func (app *App) showNote(g *gocui.Gui, index int) {
+	mark, ok := app.outputBookmark(index)
+	if !ok {
+		return
+	}
+	app.noteLine = index
+	maxX, maxY := g.Size()
+	width := min(80, maxX-2)
+	x0 := (maxX - width) / 2
+	y0 := maxY/2 - 1
+	noteView, err := g.SetView("note", x0, y0, x0+width, y0+2, 0)
+	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+		return
+	}
+	noteView.Title = " Note (Enter - save, Esc - cancel) "
+	noteView.Editable = true
+	noteView.Editor = gocui.DefaultEditor
+	noteView.Wrap = false
+	noteView.FrameColor = gocui.ColorGreen
+	noteView.TitleColor = gocui.ColorGreen
+	noteView.Clear()
+	note := ""
+	if i := app.findBookmark(mark); i != -1 {
+		note = app.bookmarks[i].Note
+	}
+	fmt.Fprint(noteView, note)
+	_ = noteView.SetCursor(len([]rune(note)), 0)
+	if _, err := g.SetCurrentView("note"); err != nil {
+		return
+	}
+}
+
+// Функция для сохранения заметки из окна ввода
+func (app *App) saveNote(g *gocui.Gui, v *gocui.View) error {
+	app.setBookmarkNote(app.noteLine, v.Buffer())
+	if err := app.closeNote(g); err != nil {
+		return err
+	}
+	app.refreshFilter()
+	return nil
+}
+
+// Функция для закрытия окна ввода заметки
+func (app *App) closeNote(g *gocui.Gui) error {
+	if err := g.DeleteView("note"); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("logs", gocui.KeyPgdn, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollDownLogs(10)
-	}); err != nil {
+	if _, err := g.SetCurrentView("logs"); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("logs", gocui.KeyPgdn, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollDownLogs(500)
-	}); err != nil {
-		return err
+	return nil
+}
+
+// Функция для вывода окна ввода номера строки для перехода в полном выводе журнала
+func (app *App) showGoToLine(g *gocui.Gui) {
+	if len(app.currentLogLines) == 0 {
+		return
 	}
-	// KeyPgup Logs 1/10/500
-	if err := app.gui.SetKeybinding("logs", gocui.KeyPgup, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollUpLogs(1)
-	}); err != nil {
-		return err
+	maxX, maxY := g.Size()
+	width := min(40, maxX-2)
+	x0 := (maxX - width) / 2
+	y0 := maxY/2 - 1
+	goToView, err := g.SetView("goToLine", x0, y0, x0+width, y0+2, 0)
+	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+		return
 	}
-	if err := app.gui.SetKeybinding("logs", gocui.KeyPgup, gocui.ModShift, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollUpLogs(10)
-	}); err != nil {
+	goToView.Title = " Go to line (Enter - go, Esc - cancel) "
+	goToView.Editable = true
+	goToView.Editor = gocui.DefaultEditor
+	goToView.Wrap = false
+	goToView.FrameColor = gocui.ColorGreen
+	goToView.TitleColor = gocui.ColorGreen
+	goToView.Clear()
+	_ = goToView.SetCursor(0, 0)
+	if _, err := g.SetCurrentView("goToLine"); err != nil {
+		return
+	}
+}
+
+// Функция для перехода к строке с введенным номером (фильтр сбрасывается)
+func (app *App) goToLine(g *gocui.Gui, v *gocui.View) error {
+	number, err := strconv.Atoi(strings.TrimSpace(v.Buffer()))
+	if err != nil || number < 1 {
+		v.Title = " Go to line (enter a line number) "
+		v.TitleColor = gocui.ColorRed
+		return nil
+	}
+	if err := app.closeGoToLine(g); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("logs", gocui.KeyPgup, gocui.ModAlt, func(g *gocui.Gui, v *gocui.View) error {
-		return app.scrollUpLogs(500)
-	}); err != nil {
+	app.showSourceLine(g, func() int { return app.findLineByNumber(number) })
+	return nil
+}
+
+// Функция для закрытия окна ввода номера строки
+func (app *App) closeGoToLine(g *gocui.Gui) error {
+	if err := g.DeleteView("goToLine"); err != nil {
 		return err
 	}
-	// Перемещение к концу журнала (Ctrl+E or End)
-	if err := app.gui.SetKeybinding("", gocui.KeyCtrlE, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		app.updateLogsView(true)
-		return nil
-	}); err != nil {
+	if _, err := g.SetCurrentView("logs"); err != nil {
 		return err
 	}
-	if err := app.gui.SetKeybinding("", gocui.KeyEnd, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		app.updateLogsView(true)
+	return nil
+}
+
+// Функция для вывода окна выгрузки текущего вывода журнала в файл
+func (app *App) showExport(g *gocui.Gui) {
+	if len(app.filteredLogLines) == 0 {
+		return
+	}
+	app.exportStructured = app.hasStructuredLines()
+	app.exportOverwrite = ""
+	if app.exportFormat == "" || app.exportFormat == "json" && !app.exportStructured {
+		app.exportFormat = "text"
+	}
+	maxX, maxY := g.Size()
+	width := min(80, maxX-2)
+	x0 := (maxX - width) / 2
+	y0 := maxY/2 - 1
+	exportView, err := g.SetView("export", x0, y0, x0+width, y0+2, 0)
+	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+		return
+	}
+	exportView.Editable = true
+	exportView.Editor = gocui.DefaultEditor
+	exportView.Wrap = false
+	exportView.FrameColor = gocui.ColorGreen
+	exportView.TitleColor = gocui.ColorGreen
+	app.updateExportTitle(exportView)
+	exportView.Clear()
+	fileName := app.defaultExportName(app.exportFormat)
+	fmt.Fprint(exportView, fileName)
+	_ = exportView.SetCursor(len([]rune(fileName)), 0)
+	if _, err := g.SetCurrentView("export"); err != nil {
+		return
+	}
+}
+
+// Функция для обновления заголовка окна выгрузки с текущим форматом
+func (app *App) updateExportTitle(v *gocui.View) {
+	v.Title = " Export (" + exportFormatNames[app.exportFormat] + "): Up/Down - format, Enter - save, Esc - cancel "
+}
+
+// Функция для переключения формата выгрузки (JSON lines доступен только для структурированных журналов)
+// Расширение в имени файла заменяется, если оно соответствует предыдущему формату
+func (app *App) switchExportFormat(v *gocui.View, step int) error {
+	formats := exportFormats
+	if !app.exportStructured {
+		formats = slices.DeleteFunc(slices.Clone(formats), func(format string) bool { return format == "json" })
+	}
+	index := slices.Index(formats, app.exportFormat)
+	nextFormat := formats[(index+step+len(formats))%len(formats)]
+	fileName := strings.TrimSpace(v.Buffer())
+	if strings.HasSuffix(fileName, exportExtensions[app.exportFormat]) {
+		fileName = strings.TrimSuffix(fileName, exportExtensions[app.exportFormat]) + exportExtensions[nextFormat]
+	}
+	app.exportFormat = nextFormat
+	app.exportOverwrite = ""
+	v.FrameColor = gocui.ColorGreen
+	v.TitleColor = gocui.ColorGreen
+	app.updateExportTitle(v)
+	v.Clear()
+	fmt.Fprint(v, fileName)
+	return v.SetCursor(len([]rune(fileName)), 0)
+}
+
+// Функция для записи текущего вывода журнала в файл из окна выгрузки
+func (app *App) saveExport(g *gocui.Gui, v *gocui.View) error {
+	fileName := strings.TrimSpace(v.Buffer())
+	if fileName == "" {
 		return nil
-	}); err != nil {
-		return err
 	}
-	// Перемещение к началу журнала (Ctrl+A or Home)
-	if err := app.gui.SetKeybinding("", gocui.KeyCtrlA, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		app.pageUpLogs()
+	// Существующий файл перезаписывается только после подтверждения
+	err := writeExportFile(fileName, app.exportLogLines(app.exportFormat), fileName == app.exportOverwrite)
+	if errors.Is(err, os.ErrExist) {
+		app.exportOverwrite = fileName
+		v.FrameColor = gocui.ColorYellow
+		v.TitleColor = gocui.ColorYellow
+		v.Title = " File already exists: Enter - overwrite, Esc - cancel "
 		return nil
-	}); err != nil {
-		return err
 	}
-	if err := app.gui.SetKeybinding("", gocui.KeyHome, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		app.pageUpLogs()
+	app.exportOverwrite = ""
+	if err != nil {
+		v.FrameColor = gocui.ColorRed
+		v.TitleColor = gocui.ColorRed
+		v.Title = " Export error: " + err.Error() + " "
 		return nil
-	}); err != nil {
+	}
+	return app.closeExport(g)
+}
+
+// Функция для записи выгрузки в файл (существующий файл перезаписывается только с параметром overwrite)
+func writeExportFile(fileName string, data []byte, overwrite bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	file, err := os.OpenFile(fileName, flags, 0o644)
+	if err != nil {
 		return err
 	}
-	// Очистка поля ввода для фильтра (Ctrl+W)
-	if err := app.gui.SetKeybinding("", gocui.KeyCtrlW, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		app.clearFilterEditor(g)
-		return nil
-	}); err != nil {
+	if _, err := file.Write(data); err != nil {
+		file.Close()
 		return err
 	}
-	// Обновить все текущие списки журналов вручную (Ctrl+R)
-	if err := app.gui.SetKeybinding("", gocui.KeyCtrlR, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		if app.getOS != "windows" {
-			app.loadServices(app.selectUnits)
-			app.loadFiles(app.selectPath)
-		} else {
-			app.loadWinFiles(app.selectPath)
-		}
-		return nil
-	}); err != nil {
+	return file.Close()
+}
+
+// Функция для закрытия окна выгрузки
+func (app *App) closeExport(g *gocui.Gui) error {
+	if err := g.DeleteView("export"); err != nil {
 		return err
 	}
-	// Выключение/включение встроенной покраски ключевых слов (Ctrl+Q)
-	if err := app.gui.SetKeybinding("", gocui.KeyCtrlQ, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		if app.colorMode {
-			app.colorMode = false
-		} else {
-			app.colorMode = true
-		}
-		if len(app.currentLogLines) != 0 {
-			app.updateLogsView(true)
-			app.applyFilter(false)
-			app.updateLogOutput(0)
-		}
-		return nil
-	}); err != nil {
+	if _, err := g.SetCurrentView("logs"); err != nil {
 		return err
 	}
-	// Включение/выключение режима покраски через tailspin (Ctrl+S)
-	if err := app.gui.SetKeybinding("", gocui.KeyCtrlS, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		if app.tailSpinMode {
-			app.tailSpinMode = false
-		} else {
-			// Проверяем, что tailspin установлен в системе
-			tsCommands := []string{"tailspin", "tspin"}
-			for _, ts := range tsCommands {
-				cmd := exec.Command(ts, "--version")
-				_, err := cmd.Output()
-				if err == nil {
-					app.tailSpinMode = true
-				}
-			}
-		}
-		if len(app.currentLogLines) != 0 {
-			app.updateLogsView(true)
-			app.applyFilter(false)
-			app.updateLogOutput(0)
+	return nil
+}
+
+// Функция для получения исходных строк журнала, которые есть в текущем выводе (с учетом фильтра и без делимитра обновления)
+// Остальные строки заменяются пустыми, что бы индексы строк шаблонов соответствовали currentLogLines
+func (app *App) patternSourceLines() []string {
+	lines := make([]string, len(app.currentLogLines))
+	for _, line := range app.filteredLines {
+		if line.index >= 0 && line.index < len(lines) && !app.isDelimiterLine(line.index) {
+			lines[line.index] = app.currentLogLines[line.index]
 		}
-		return nil
-	}); err != nil {
-		return err
 	}
-	// Отключить окно справки (F1)
-	if err := app.gui.SetKeybinding("", gocui.KeyF1, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		app.showInterfaceHelp(g)
-		return nil
-	}); err != nil {
+	return lines
+}
+
+// Функция для вывода окна с шаблонами сообщений текущего журнала
+func (app *App) showPatterns(g *gocui.Gui) {
+	if len(app.currentLogLines) == 0 {
+		return
+	}
+	app.logPatterns = buildLogPatterns(app.patternSourceLines())
+	maxX, maxY := g.Size()
+	patternsView, err := g.SetView("patterns", 4, 2, maxX-5, maxY-3, 0)
+	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+		return
+	}
+	patternsView.Title = fmt.Sprintf(" Patterns (%d): count, distribution, first and last seen, template ", len(app.logPatterns))
+	patternsView.Highlight = true
+	patternsView.Wrap = false
+	patternsView.Autoscroll = false
+	patternsView.FrameColor = gocui.ColorGreen
+	patternsView.TitleColor = gocui.ColorGreen
+	patternsView.SelBgColor = gocui.ColorGreen
+	patternsView.SelFgColor = gocui.ColorBlack
+	patternsView.Clear()
+	for _, pattern := range app.logPatterns {
+		fmt.Fprintln(patternsView, app.formatLogPattern(pattern))
+	}
+	_ = patternsView.SetOrigin(0, 0)
+	_ = patternsView.SetCursor(0, 0)
+	if _, err := g.SetCurrentView("patterns"); err != nil {
+		return
+	}
+}
+
+// Функция для перемещения по списку во всплывающем окне (шаблоны, закладки) с прокруткой окна
+func (app *App) movePopupCursor(v *gocui.View, step int, count int) error {
+	_, viewHeight := v.Size()
+	_, originY := v.Origin()
+	_, cursorY := v.Cursor()
+	index := max(0, min(originY+cursorY+step, count-1))
+	switch {
+	case index < originY:
+		originY = index
+	case index >= originY+viewHeight:
+		originY = index - viewHeight + 1
+	}
+	if err := v.SetOrigin(0, originY); err != nil {
 		return err
 	}
-	// Закрыть окно справки (Esc)
-	if err := app.gui.SetKeybinding("", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		if err := app.closeHelp(g); err != nil {
-			return nil
-		}
+	return v.SetCursor(0, index-originY)
+}
+
+// Функция для фильтрации вывода журнала по выбранному шаблону
+func (app *App) selectPattern(g *gocui.Gui, v *gocui.View) error {
+	_, originY := v.Origin()
+	_, cursorY := v.Cursor()
+	index := originY + cursorY
+	if index >= len(app.logPatterns) {
 		return nil
-	}); err != nil {
+	}
+	app.patternFilter = app.logPatterns[index].masks
+	if err := app.closePatterns(g); err != nil {
 		return err
 	}
+	app.autoScroll = true
+	app.refreshFilter()
 	return nil
 }
 
-func (app *App) showInterfaceHelp(g *gocui.Gui) {
-	// Получаем размеры терминала
-	maxX, maxY := g.Size()
-	// Размеры окна help
-	width, height := 104, 29
-	// Вычисляем координаты для центрального расположения
-	x0 := (maxX - width) / 2
-	y0 := (maxY - height) / 2
-	x1 := x0 + width
-	y1 := y0 + height
-	helpView, err := g.SetView("help", x0, y0, x1, y1, 0)
-	if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
-		return
+// Функция для закрытия окна шаблонов и возврата к выводу журнала
+func (app *App) closePatterns(g *gocui.Gui) error {
+	if err := g.DeleteView("patterns"); err != nil {
+		return err
 	}
-	helpView.Title = " Help "
-	helpView.Autoscroll = true
-	helpView.Wrap = true
-	helpView.FrameColor = gocui.ColorGreen
-	helpView.TitleColor = gocui.ColorGreen
-	helpView.Clear()
-	fmt.Fprintln(helpView, "\n  \033[33mlazyjournal\033[0m - terminal user interface for reading logs from journalctl, file system, Docker and")
-	fmt.Fprintln(helpView, "  Podman containers, as well Kubernetes pods.")
-	fmt.Fprintln(helpView, "\n  Version: \033[36m"+programVersion+"\033[0m")
-	fmt.Fprintln(helpView, "\n  Hotkeys:")
-	fmt.Fprintln(helpView, "\n  \033[32mTab\033[0m - switch between windows.")
-	fmt.Fprintln(helpView, "  \033[32mShift+Tab\033[0m - return to previous window.")
-	fmt.Fprintln(helpView, "  \033[32mLeft/Right\033[0m - switch between journal lists in the selected window.")
-	fmt.Fprintln(helpView, "  \033[32mEnter\033[0m - selection a journal from the list to display log output.")
-	fmt.Fprintln(helpView, "  \033[32m<Up/PgUp>\033[0m and \033[32m<Down/PgDown>\033[0m - move up and down through all journal lists and log output,")
-	fmt.Fprintln(helpView, "  as well as changing the filtering mode in the filter window.")
-	fmt.Fprintln(helpView, "  \033[32m<Shift/Alt>+<Up/Down>\033[0m - quickly move up and down through all journal lists and log output")
-	fmt.Fprintln(helpView, "  every 10 or 100 lines (500 for log output).")
-	fmt.Fprintln(helpView, "  \033[32m<Shift/Ctrl>+<U/D>\033[0m - quickly move up and down (alternative for macOS).")
-	fmt.Fprintln(helpView, "  \033[32mCtrl+A\033[0m or \033[32mHome\033[0m - go to top of log.")
-	fmt.Fprintln(helpView, "  \033[32mCtrl+E\033[0m or \033[32mEnd\033[0m - go to the end of the log.")
-	fmt.Fprintln(helpView, "  \033[32mCtrl+Q\033[0m - enable or disable built-in output coloring.")
-	fmt.Fprintln(helpView, "  \033[32mCtrl+S\033[0m - enable or disable coloring via tailspin.")
-	fmt.Fprintln(helpView, "  \033[32mCtrl+R\033[0m - update all log lists.")
-	fmt.Fprintln(helpView, "  \033[32mCtrl+W\033[0m - clear text input field for filter to quickly update current log output without filtering.")
-	fmt.Fprintln(helpView, "  \033[32mCtrl+C\033[0m - exit.")
-	fmt.Fprintln(helpView, "  \033[32mEscape\033[0m - close help.")
-	fmt.Fprintln(helpView, "\n  Source code: \033[35mhttps://github.com/Lifailon/lazyjournal\033[0m")
+	if _, err := g.SetCurrentView("logs"); err != nil {
+		return err
+	}
+	return nil
 }
 
 func (app *App) closeHelp(g *gocui.Gui) error {
@@ -4344,7 +11314,7 @@ func (app *App) setLogFilesListRight(g *gocui.Gui, v *gocui.View) error {
 				app.selectPath = "descriptor"
 				selectedVarLog.Title = " < Process descriptor logs (0) > "
 				app.loadFiles(app.selectPath)
-			case "descriptor":
+			case "descriptor", "container":
 				app.selectPath = "/var/log/"
 				selectedVarLog.Title = " < System var logs (0) > "
 				app.loadFiles(app.selectPath)
@@ -4422,7 +11392,7 @@ func (app *App) setLogFilesListLeft(g *gocui.Gui, v *gocui.View) error {
 				app.selectPath = "/opt/"
 				selectedVarLog.Title = " < Optional package logs (0) > "
 				app.loadFiles(app.selectPath)
-			case "/opt/":
+			case "/opt/", "container":
 				app.selectPath = "/var/log/"
 				selectedVarLog.Title = " < System var logs (0) > "
 				app.loadFiles(app.selectPath)
@@ -4436,8 +11406,62 @@ func (app *App) setLogFilesListLeft(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+func (app *App) setContainersListRight(g *gocui.Gui, v *gocui.View) error {
+	selectedDocker, err := g.View("docker")
+	if err != nil {
+		log.Panicln(err)
+	}
+	app.dockerContainers = app.dockerContainers[:0]
+	app.startDockerContainers = 0
+	app.selectedDockerContainer = 0
+	switch app.selectContainerizationSystem {
+	case "docker":
+		app.selectContainerizationSystem = "podman"
+		selectedDocker.Title = " < Podman containers (0) > "
+		app.loadDockerContainer(app.selectContainerizationSystem)
+	case "podman":
+		app.selectContainerizationSystem = "kubectl"
+		app.kubeNamespace, app.kubePod = "", ""
+		selectedDocker.Title = app.kubeTitle()
+		app.loadDockerContainer(app.selectContainerizationSystem)
+	case "kubectl":
+		app.selectContainerizationSystem = "docker"
+		selectedDocker.Title = " < Docker containers (0) > "
+		app.loadDockerContainer(app.selectContainerizationSystem)
+	}
+	return nil
+}
+
+func (app *App) setContainersListLeft(g *gocui.Gui, v *gocui.View) error {
+	selectedDocker, err := g.View("docker")
+	if err != nil {
+		log.Panicln(err)
+	}
+	app.dockerContainers = app.dockerContainers[:0]
+	app.startDockerContainers = 0
+	app.selectedDockerContainer = 0
+	switch app.selectContainerizationSystem {
+	case "docker":
+		app.selectContainerizationSystem = "kubectl"
+		app.kubeNamespace, app.kubePod = "", ""
+		selectedDocker.Title = app.kubeTitle()
+		app.loadDockerContainer(app.selectContainerizationSystem)
+	case "kubectl":
+		app.selectContainerizationSystem = "podman"
+		selectedDocker.Title = " < Podman containers (0) > "
+		app.loadDockerContainer(app.selectContainerizationSystem)
+	case "podman":
+		app.selectContainerizationSystem = "docker"
+		selectedDocker.Title = " < Docker containers (0) > "
+		app.loadDockerContainer(app.selectContainerizationSystem)
+	}
+	return nil
+}
+
 // Функция для переключения окон через Tab
 func (app *App) nextView(g *gocui.Gui, v *gocui.View) error {
+	// Переключение окон отключает полноэкранный режим вывода журнала
+	app.zoomLogs = false
 	selectedFilterList, err := g.View("filterList")
 	if err != nil {
 		log.Panicln(err)
@@ -4450,6 +11474,10 @@ func (app *App) nextView(g *gocui.Gui, v *gocui.View) error {
 	if err != nil {
 		log.Panicln(err)
 	}
+	selectedDocker, err := g.View("docker")
+	if err != nil {
+		log.Panicln(err)
+	}
 	selectedFilter, err := g.View("filter")
 	if err != nil {
 		log.Panicln(err)
@@ -4477,6 +11505,8 @@ func (app *App) nextView(g *gocui.Gui, v *gocui.View) error {
 			selectedServices.TitleColor = gocui.ColorGreen
 			selectedVarLog.FrameColor = app.fileSystemFrameColor
 			selectedVarLog.TitleColor = gocui.ColorDefault
+			selectedDocker.FrameColor = app.dockerFrameColor
+			selectedDocker.TitleColor = gocui.ColorDefault
 			selectedFilter.FrameColor = gocui.ColorDefault
 			selectedFilter.TitleColor = gocui.ColorDefault
 			selectedLogs.FrameColor = gocui.ColorDefault
@@ -4490,23 +11520,43 @@ func (app *App) nextView(g *gocui.Gui, v *gocui.View) error {
 			selectedServices.TitleColor = gocui.ColorDefault
 			selectedVarLog.FrameColor = gocui.ColorGreen
 			selectedVarLog.TitleColor = gocui.ColorGreen
+			selectedDocker.FrameColor = app.dockerFrameColor
+			selectedDocker.TitleColor = gocui.ColorDefault
 			selectedFilter.FrameColor = gocui.ColorDefault
 			selectedFilter.TitleColor = gocui.ColorDefault
 			selectedLogs.FrameColor = gocui.ColorDefault
 			selectedLogs.TitleColor = gocui.ColorDefault
 			selectedScrollLogs.FrameColor = gocui.ColorDefault
 		case "varLogs":
+			nextView = "docker"
 			selectedFilterList.FrameColor = gocui.ColorDefault
 			selectedFilterList.TitleColor = gocui.ColorDefault
 			selectedServices.FrameColor = app.journalListFrameColor
 			selectedServices.TitleColor = gocui.ColorDefault
 			selectedVarLog.FrameColor = app.fileSystemFrameColor
 			selectedVarLog.TitleColor = gocui.ColorDefault
+			selectedDocker.FrameColor = gocui.ColorGreen
+			selectedDocker.TitleColor = gocui.ColorGreen
 			selectedFilter.FrameColor = gocui.ColorDefault
 			selectedFilter.TitleColor = gocui.ColorDefault
 			selectedLogs.FrameColor = gocui.ColorDefault
 			selectedLogs.TitleColor = gocui.ColorDefault
 			selectedScrollLogs.FrameColor = gocui.ColorDefault
+		case "docker":
+			nextView = "filter"
+			selectedFilterList.FrameColor = gocui.ColorDefault
+			selectedFilterList.TitleColor = gocui.ColorDefault
+			selectedServices.FrameColor = app.journalListFrameColor
+			selectedServices.TitleColor = gocui.ColorDefault
+			selectedVarLog.FrameColor = app.fileSystemFrameColor
+			selectedVarLog.TitleColor = gocui.ColorDefault
+			selectedDocker.FrameColor = app.dockerFrameColor
+			selectedDocker.TitleColor = gocui.ColorDefault
+			selectedFilter.FrameColor = gocui.ColorGreen
+			selectedFilter.TitleColor = gocui.ColorGreen
+			selectedLogs.FrameColor = gocui.ColorDefault
+			selectedLogs.TitleColor = gocui.ColorDefault
+			selectedScrollLogs.FrameColor = gocui.ColorDefault
 		case "filter":
 			nextView = "logs"
 			selectedFilterList.FrameColor = gocui.ColorDefault
@@ -4515,12 +11565,14 @@ func (app *App) nextView(g *gocui.Gui, v *gocui.View) error {
 			selectedServices.TitleColor = gocui.ColorDefault
 			selectedVarLog.FrameColor = app.fileSystemFrameColor
 			selectedVarLog.TitleColor = gocui.ColorDefault
+			selectedDocker.FrameColor = app.dockerFrameColor
+			selectedDocker.TitleColor = gocui.ColorDefault
 			selectedFilter.FrameColor = gocui.ColorDefault
 			selectedFilter.TitleColor = gocui.ColorDefault
 			selectedLogs.FrameColor = gocui.ColorGreen
 			selectedLogs.TitleColor = gocui.ColorGreen
 			selectedScrollLogs.FrameColor = gocui.ColorGreen
-		case "logs":
+		case "logs", "timeline":
 			nextView = "filterList"
 			selectedFilterList.FrameColor = gocui.ColorGreen
 			selectedFilterList.TitleColor = gocui.ColorGreen
@@ -4528,6 +11580,8 @@ func (app *App) nextView(g *gocui.Gui, v *gocui.View) error {
 			selectedServices.TitleColor = gocui.ColorDefault
 			selectedVarLog.FrameColor = app.fileSystemFrameColor
 			selectedVarLog.TitleColor = gocui.ColorDefault
+			selectedDocker.FrameColor = app.dockerFrameColor
+			selectedDocker.TitleColor = gocui.ColorDefault
 			selectedFilter.FrameColor = gocui.ColorDefault
 			selectedFilter.TitleColor = gocui.ColorDefault
 			selectedLogs.FrameColor = gocui.ColorDefault
@@ -4544,6 +11598,8 @@ func (app *App) nextView(g *gocui.Gui, v *gocui.View) error {
 
 // Функция для переключения окон в обратном порядке через Shift+Tab
 func (app *App) backView(g *gocui.Gui, v *gocui.View) error {
+	// Переключение окон отключает полноэкранный режим вывода журнала
+	app.zoomLogs = false
 	selectedFilterList, err := g.View("filterList")
 	if err != nil {
 		log.Panicln(err)
@@ -4556,6 +11612,10 @@ func (app *App) backView(g *gocui.Gui, v *gocui.View) error {
 	if err != nil {
 		log.Panicln(err)
 	}
+	selectedDocker, err := g.View("docker")
+	if err != nil {
+		log.Panicln(err)
+	}
 	selectedFilter, err := g.View("filter")
 	if err != nil {
 		log.Panicln(err)
@@ -4582,6 +11642,8 @@ func (app *App) backView(g *gocui.Gui, v *gocui.View) error {
 			selectedServices.TitleColor = gocui.ColorDefault
 			selectedVarLog.FrameColor = app.fileSystemFrameColor
 			selectedVarLog.TitleColor = gocui.ColorDefault
+			selectedDocker.FrameColor = app.dockerFrameColor
+			selectedDocker.TitleColor = gocui.ColorDefault
 			selectedFilter.FrameColor = gocui.ColorDefault
 			selectedFilter.TitleColor = gocui.ColorDefault
 			selectedLogs.FrameColor = gocui.ColorGreen
@@ -4595,12 +11657,14 @@ func (app *App) backView(g *gocui.Gui, v *gocui.View) error {
 			selectedServices.TitleColor = gocui.ColorDefault
 			selectedVarLog.FrameColor = app.fileSystemFrameColor
 			selectedVarLog.TitleColor = gocui.ColorDefault
+			selectedDocker.FrameColor = app.dockerFrameColor
+			selectedDocker.TitleColor = gocui.ColorDefault
 			selectedFilter.FrameColor = gocui.ColorDefault
 			selectedFilter.TitleColor = gocui.ColorDefault
 			selectedLogs.FrameColor = gocui.ColorDefault
 			selectedLogs.TitleColor = gocui.ColorDefault
 			selectedScrollLogs.FrameColor = gocui.ColorDefault
-		case "logs":
+		case "logs", "timeline":
 			nextView = "filter"
 			selectedFilterList.FrameColor = gocui.ColorDefault
 			selectedFilterList.TitleColor = gocui.ColorDefault
@@ -4608,18 +11672,38 @@ func (app *App) backView(g *gocui.Gui, v *gocui.View) error {
 			selectedServices.TitleColor = gocui.ColorDefault
 			selectedVarLog.FrameColor = app.fileSystemFrameColor
 			selectedVarLog.TitleColor = gocui.ColorDefault
+			selectedDocker.FrameColor = app.dockerFrameColor
+			selectedDocker.TitleColor = gocui.ColorDefault
 			selectedFilter.FrameColor = gocui.ColorGreen
 			selectedFilter.TitleColor = gocui.ColorGreen
 			selectedLogs.FrameColor = gocui.ColorDefault
 			selectedLogs.TitleColor = gocui.ColorDefault
 			selectedScrollLogs.FrameColor = gocui.ColorDefault
 		case "filter":
+			nextView = "docker"
 			selectedFilterList.FrameColor = gocui.ColorDefault
 			selectedFilterList.TitleColor = gocui.ColorDefault
 			selectedServices.FrameColor = app.journalListFrameColor
 			selectedServices.TitleColor = gocui.ColorDefault
 			selectedVarLog.FrameColor = app.fileSystemFrameColor
 			selectedVarLog.TitleColor = gocui.ColorDefault
+			selectedDocker.FrameColor = gocui.ColorGreen
+			selectedDocker.TitleColor = gocui.ColorGreen
+			selectedFilter.FrameColor = gocui.ColorDefault
+			selectedFilter.TitleColor = gocui.ColorDefault
+			selectedLogs.FrameColor = gocui.ColorDefault
+			selectedLogs.TitleColor = gocui.ColorDefault
+			selectedScrollLogs.FrameColor = gocui.ColorDefault
+		case "docker":
+			nextView = "varLogs"
+			selectedFilterList.FrameColor = gocui.ColorDefault
+			selectedFilterList.TitleColor = gocui.ColorDefault
+			selectedServices.FrameColor = app.journalListFrameColor
+			selectedServices.TitleColor = gocui.ColorDefault
+			selectedVarLog.FrameColor = gocui.ColorGreen
+			selectedVarLog.TitleColor = gocui.ColorGreen
+			selectedDocker.FrameColor = app.dockerFrameColor
+			selectedDocker.TitleColor = gocui.ColorDefault
 			selectedFilter.FrameColor = gocui.ColorDefault
 			selectedFilter.TitleColor = gocui.ColorDefault
 			selectedLogs.FrameColor = gocui.ColorDefault
@@ -4633,6 +11717,8 @@ func (app *App) backView(g *gocui.Gui, v *gocui.View) error {
 			selectedServices.TitleColor = gocui.ColorGreen
 			selectedVarLog.FrameColor = app.fileSystemFrameColor
 			selectedVarLog.TitleColor = gocui.ColorDefault
+			selectedDocker.FrameColor = app.dockerFrameColor
+			selectedDocker.TitleColor = gocui.ColorDefault
 			selectedFilter.FrameColor = gocui.ColorDefault
 			selectedFilter.TitleColor = gocui.ColorDefault
 			selectedLogs.FrameColor = gocui.ColorDefault